@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// angleUnits are the units the value can be read as an angle in. Degrees,
+// radians and gradians make a full turn of 360, 2π and 400.
+var angleUnits = []struct {
+	name string
+	turn float64
+}{
+	{"deg", 360},
+	{"rad", 2 * math.Pi},
+	{"grad", 400},
+}
+
+// nextAngleUnit returns the unit following u, where 0 is off and i+1 is
+// angleUnits[i], wrapping around to off.
+func nextAngleUnit(u int) int {
+	return (u + 1) % (len(angleUnits) + 1)
+}
+
+// angleView shows the value, read as an angle in the unit u, in the other
+// units, like "rad: 3.14159 grad: 200" for 180 degrees.
+func angleView(u int, v uint64) string {
+	from := angleUnits[u-1]
+	turns := float64(v) / from.turn
+
+	var parts []string
+	for _, to := range angleUnits {
+		if to.name != from.name {
+			parts = append(parts, fmt.Sprintf("%s: %.6g", to.name, turns*to.turn))
+		}
+	}
+	return fmt.Sprintf("\nangle (%s): %s\n", from.name, strings.Join(parts, " "))
+}
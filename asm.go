@@ -0,0 +1,43 @@
+package main
+
+import "fmt"
+
+const defaultAsmSyntax = "nasm"
+
+// asmSyntaxes write the value as an immediate operand for each assembler
+// syntax: NASM's suffixed hex, AT&T's $-prefixed hex, and the 0x hex of GAS
+// in Intel mode.
+var asmSyntaxes = map[string]func(uint64) string{
+	"nasm": nasmImmediate,
+	"att":  func(v uint64) string { return "$" + literal(v, Hexadecimal) },
+	"gas":  func(v uint64) string { return literal(v, Hexadecimal) },
+}
+
+// nasmImmediate returns v in hex with the h suffix, led by a 0 if it would
+// start with a letter, like 0FFh.
+func nasmImmediate(v uint64) string {
+	s := formatValue(v, Hexadecimal)
+	if s[0] > '9' {
+		s = "0" + s
+	}
+	return s + literalSuffix(Hexadecimal)
+}
+
+// checkAsmSyntax returns an error if there is no assembler syntax called
+// name. An empty name is the default syntax.
+func checkAsmSyntax(name string) error {
+	if _, ok := asmSyntaxes[name]; !ok && len(name) > 0 {
+		return fmt.Errorf("unknown assembler syntax %q, using %s", name, defaultAsmSyntax)
+	}
+	return nil
+}
+
+// asmImmediate returns the value as an immediate in the configured
+// assembler syntax, or the default one.
+func (m model) asmImmediate() string {
+	immediate, ok := asmSyntaxes[m.asmSyntax]
+	if !ok {
+		immediate = asmSyntaxes[defaultAsmSyntax]
+	}
+	return immediate(m.value())
+}
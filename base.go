@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxRecentBases is how many recently used bases are kept for switching
+// between.
+const maxRecentBases = 5
+
+// setBase shows the value in the base written in input, from 2 to 36.
+func (m *model) setBase(input string) error {
+	base, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || base < 2 || base > 36 {
+		return errMsg{fmt.Sprintf("invalid base %q, expected 2-36", input)}
+	}
+	m.base = base
+	m.useBase(base)
+	return nil
+}
+
+// useBase moves base to the front of the recently used bases.
+func (m *model) useBase(base int) {
+	recent := []int{base}
+	for _, b := range m.recentBases {
+		if b != base && len(recent) < maxRecentBases {
+			recent = append(recent, b)
+		}
+	}
+	m.recentBases = recent
+}
+
+// cycleBase shows the value in the recently used base after the one shown,
+// going through decimal after the least recent one.
+func (m *model) cycleBase() {
+	bases := m.recentBases
+	if !containsBase(bases, 10) {
+		bases = append(bases[:len(bases):len(bases)], 10)
+	}
+
+	for i, b := range bases {
+		if b == m.base {
+			m.base = bases[(i+1)%len(bases)]
+			return
+		}
+	}
+	m.base = bases[0]
+}
+
+func containsBase(bases []int, base int) bool {
+	for _, b := range bases {
+		if b == base {
+			return true
+		}
+	}
+	return false
+}
+
+func (m model) baseView() string {
+	return fmt.Sprintf("\nbase %d: %s\n", m.base, strings.ToUpper(strconv.FormatUint(m.value(), m.base)))
+}
@@ -0,0 +1,44 @@
+package main
+
+import "strings"
+
+const bigDigitHeight = 5
+
+var bigFont = map[rune][bigDigitHeight]string{
+	'0': {"███", "█ █", "█ █", "█ █", "███"},
+	'1': {" █ ", "██ ", " █ ", " █ ", "███"},
+	'2': {"███", "  █", "███", "█  ", "███"},
+	'3': {"███", "  █", "███", "  █", "███"},
+	'4': {"█ █", "█ █", "███", "  █", "  █"},
+	'5': {"███", "█  ", "███", "  █", "███"},
+	'6': {"███", "█  ", "███", "█ █", "███"},
+	'7': {"███", "  █", "  █", "  █", "  █"},
+	'8': {"███", "█ █", "███", "█ █", "███"},
+	'9': {"███", "█ █", "███", "  █", "███"},
+	'A': {"███", "█ █", "███", "█ █", "█ █"},
+	'B': {"██ ", "█ █", "██ ", "█ █", "██ "},
+	'C': {"███", "█  ", "█  ", "█  ", "███"},
+	'D': {"██ ", "█ █", "█ █", "█ █", "██ "},
+	'E': {"███", "█  ", "███", "█  ", "███"},
+	'F': {"███", "█  ", "███", "█  ", "█  "},
+}
+
+// bigDigits renders the digits of s in large block characters, one line
+// per row of the font.
+func bigDigits(s string) []string {
+	if len(s) == 0 {
+		s = "0"
+	}
+
+	lines := make([]string, bigDigitHeight)
+	for i, c := range strings.ToUpper(s) {
+		glyph := bigFont[c]
+		for row := range lines {
+			if i > 0 {
+				lines[row] += " "
+			}
+			lines[row] += glyph[row]
+		}
+	}
+	return lines
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bitOps are the operations of the bit prompt.
+var bitOps = map[string]func(v, bit uint64) uint64{
+	"set":   func(v, bit uint64) uint64 { return v | bit },
+	"clear": func(v, bit uint64) uint64 { return v &^ bit },
+	"flip":  func(v, bit uint64) uint64 { return v ^ bit },
+}
+
+// openBitPrompt opens the prompt for setting, clearing or flipping a bit,
+// noting how its index is read.
+func (m *model) openBitPrompt() {
+	label := "set, clear or flip bit"
+	if m.relativeBits {
+		if bit, err := m.cursorBit(); err == nil {
+			label += fmt.Sprintf(" (relative to bit %d)", bit)
+		}
+	}
+	m.openPrompt(label, (*model).bitOp)
+}
+
+// bitOp applies the operation written in input, like "set 3", to a bit of
+// the value.
+func (m *model) bitOp(input string) error {
+	fields := strings.Fields(input)
+	if len(fields) != 2 {
+		return errMsg{"expected an operation and a bit, like set 3"}
+	}
+	op, ok := bitOps[fields[0]]
+	if !ok {
+		return errMsg{fmt.Sprintf("unknown operation %q, expected set, clear or flip", fields[0])}
+	}
+	bit, err := m.bitIndex(fields[1])
+	if err != nil {
+		return err
+	}
+
+	m.setValue(op(m.value(), 1<<bit))
+	return nil
+}
+
+// bitIndex reads s as a bit index counted from the LSB, or, if relativeBits
+// is set, as an offset towards the MSB from the bit under the cursor.
+func (m model) bitIndex(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errMsg{fmt.Sprintf("invalid bit %q", s)}
+	}
+	if m.relativeBits {
+		cursor, err := m.cursorBit()
+		if err != nil {
+			return 0, err
+		}
+		n += cursor
+	}
+	if n < 0 || n > 63 {
+		return 0, errMsg{fmt.Sprintf("bit %d is out of 0-63", n)}
+	}
+	return n, nil
+}
+
+// cursorBit returns the lowest bit of the digit under the cursor. Past the
+// last digit, that is the last digit.
+func (m model) cursorBit() (int, error) {
+	var bits int
+	switch m.mode {
+	case Binary:
+		bits = 1
+	case Octal:
+		bits = 3
+	case Hexadecimal:
+		bits = 4
+	default:
+		return 0, errMsg{"relative bits need the cursor in the bin, oct or hex row"}
+	}
+	pos := min(m.cursorPos, len(m.input[m.mode])-1)
+	return max(len(m.input[m.mode])-1-pos, 0) * bits, nil
+}
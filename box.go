@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	boxStyle      = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	boxTitleStyle = lipgloss.NewStyle().Bold(true)
+)
+
+// boxLines and boxColumns are how many lines and columns box mode adds
+// around the content: the border and the title line, and the border and
+// padding either side.
+const (
+	boxLines   = 3
+	boxColumns = 4
+)
+
+// chromeLines returns how many lines of the terminal height the view
+// takes up around the content, counting the status bar and its rule in box
+// mode.
+func (m model) chromeLines() int {
+	if !m.box {
+		return 0
+	}
+	if len(m.statusBar()) > 0 {
+		return boxLines + 2
+	}
+	return boxLines
+}
+
+// chromeColumns returns how many columns of the terminal width the view
+// takes up around the content.
+func (m model) chromeColumns() int {
+	if m.box {
+		return boxColumns
+	}
+	return 0
+}
+
+// boxed frames view in a border, with the title above it and the status
+// bar below a rule, kept within the terminal width if it is known.
+func (m model) boxed(view string) string {
+	title := m.title()
+	bar := m.statusBar()
+	body := strings.TrimSuffix(view, "\n")
+
+	width := max(lipgloss.Width(title), max(lipgloss.Width(body), lipgloss.Width(bar)))
+	if m.columns > 0 {
+		width = max(0, min(width, m.columns-boxColumns))
+	}
+
+	parts := []string{boxTitleStyle.Render(title), body}
+	if len(bar) > 0 {
+		parts = append(parts, strings.Repeat("─", width), bar)
+	}
+	return boxStyle.Width(width+2).Render(strings.Join(parts, "\n")) + "\n"
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// digitsPerByte returns the number of digits of radix r making up a byte, or
+// 0 if bytes don't align to digits of r.
+func digitsPerByte(r radix) int {
+	switch r {
+	case Binary:
+		return 8
+	case Hexadecimal:
+		return 2
+	}
+	return 0
+}
+
+// insertByte inserts the byte written as two hex digits in input at the byte
+// boundary at or before the cursor.
+func (m *model) insertByte(input string) error {
+	b, err := strconv.ParseUint(input, 16, 8)
+	if len(input) != 2 || err != nil {
+		return errMsg{"a byte must be exactly two hex digits"}
+	}
+
+	n := digitsPerByte(m.mode)
+	if n == 0 {
+		return errMsg{"bytes can only be inserted in binary or hex"}
+	}
+
+	s := m.input[m.mode]
+	pad := (n - len(s)%n) % n
+	s = strings.Repeat("0", pad) + s
+	pos := m.cursorPos + pad
+	pos -= pos % n
+
+	digits := format(b, m.mode)
+	s = s[:pos] + strings.Repeat("0", n-len(digits)) + digits + s[pos:]
+
+	v, err := parse(s, m.mode)
+	if err != nil {
+		return errMsg{"value exceeds 64 bits"}
+	}
+
+	m.setValue(v)
+	m.updateCursor(pos + n - (len(s) - len(m.input[m.mode])))
+	return nil
+}
+
+// asciiValue returns the big-endian integer made of the bytes of the ASCII
+// string s.
+func asciiValue(s string) (uint64, error) {
+	var v uint64
+	for _, c := range s {
+		if c > unicode.MaxASCII {
+			return 0, errMsg{"not an ASCII string"}
+		}
+		v = v<<8 | uint64(c)
+	}
+
+	if len(s) > 8 {
+		return 0, errMsg{"at most 8 characters fit in 64 bits"}
+	}
+	return v, nil
+}
+
+func (m *model) enterASCII(input string) error {
+	v, err := asciiValue(input)
+	if err != nil {
+		return err
+	}
+
+	m.setValue(v)
+	return nil
+}
+
+// loadFromFile reads the bytes described by input, "path offset [length]
+// [le]", as a big-endian value, or a little-endian one with le. The length
+// defaults to the bytes of the current width. The path may contain spaces.
+func (m *model) loadFromFile(input string) error {
+	fields := strings.Fields(input)
+	littleEndian := false
+	if len(fields) > 2 && (fields[len(fields)-1] == "le" || fields[len(fields)-1] == "be") {
+		littleEndian = fields[len(fields)-1] == "le"
+		fields = fields[:len(fields)-1]
+	}
+	if len(fields) < 2 {
+		return errMsg{"expected a path, an offset and optionally a length"}
+	}
+
+	// The last field is a length only if the one before it is an offset,
+	// so a path with spaces followed by just an offset reads as such.
+	length := uint64((m.width + 7) / 8)
+	if len(fields) > 2 && isOffset(fields[len(fields)-2]) {
+		n, err := strconv.ParseUint(fields[len(fields)-1], 0, 64)
+		if err != nil {
+			return errMsg{fmt.Sprintf("invalid length %q", fields[len(fields)-1])}
+		}
+		length = n
+		fields = fields[:len(fields)-1]
+	}
+	if length < 1 || length > 8 {
+		return errMsg{"the length must be 1-8 bytes"}
+	}
+
+	offset, err := strconv.ParseInt(fields[len(fields)-1], 0, 64)
+	if err != nil || offset < 0 {
+		return errMsg{fmt.Sprintf("invalid offset %q", fields[len(fields)-1])}
+	}
+	path := strings.Join(fields[:len(fields)-1], " ")
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	buf := make([]byte, length)
+	if _, err := f.ReadAt(buf, offset); err == io.EOF {
+		return errMsg{fmt.Sprintf("%s has no %d bytes at offset %d", path, length, offset)}
+	} else if err != nil {
+		return err
+	}
+
+	if littleEndian {
+		slices.Reverse(buf)
+	}
+	var v uint64
+	for _, b := range buf {
+		v = v<<8 | uint64(b)
+	}
+	m.setValue(v)
+	return nil
+}
+
+// isOffset reports whether s is a valid file offset.
+func isOffset(s string) bool {
+	n, err := strconv.ParseInt(s, 0, 64)
+	return err == nil && n >= 0
+}
@@ -0,0 +1,402 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// output is a base to print values in: one of the radixes, or a registered
+// Formatter if custom is set.
+type output struct {
+	radix  radix
+	custom Formatter
+}
+
+type cliOptions struct {
+	// from is the base of the input values. If detect is set, the base is
+	// detected from each value's prefix or suffix instead.
+	from   radix
+	detect bool
+	to     []output
+
+	// template, if set, formats each value instead of the output bases.
+	template *template.Template
+
+	// via, if hasVia is set, is a base each value is converted to and back
+	// before output, failing if the round trip changes it.
+	via    radix
+	hasVia bool
+
+	// width is the number of bits values must fit in. If signed is set,
+	// negative values are accepted and stored in two's complement.
+	width  int
+	signed bool
+
+	// pad is the number of digits output values are zero-padded to, and
+	// unit, if set, is appended to them.
+	pad  int
+	unit string
+
+	// fracDigits caps the fractional digits of values with a radix point,
+	// like 0.1, and rounding is how the digits cut off are rounded.
+	fracDigits int
+	rounding   rounding
+
+	// out is where output values are written.
+	out io.Writer
+}
+
+// result is a value in every base, as seen by -format templates.
+type result struct {
+	Value              uint64
+	Bin, Oct, Dec, Hex string
+}
+
+// newCLIOptions builds the options from the command-line flags. A non-empty
+// view replaces the output bases with the extra row of that label, and a
+// non-empty format with the template it holds.
+func newCLIOptions(from, to, view, via, format, unit string, width, pad int, signed bool) (cliOptions, error) {
+	opts := cliOptions{from: Decimal, detect: len(from) == 0, width: width, signed: signed, hasVia: len(via) > 0, pad: pad, unit: unit, out: os.Stdout}
+
+	if width < 1 || width > 64 {
+		return opts, fmt.Errorf("width %d is out of 1-64", width)
+	}
+	if pad < 0 {
+		return opts, fmt.Errorf("pad %d is negative", pad)
+	}
+
+	if !opts.detect {
+		r, err := parseRadix(from)
+		if err != nil {
+			return opts, err
+		}
+		opts.from = r
+	}
+
+	if opts.hasVia {
+		r, err := parseRadix(via)
+		if err != nil {
+			return opts, err
+		}
+		opts.via = r
+	}
+
+	if len(format) > 0 {
+		t, err := template.New("format").Parse(format)
+		if err != nil {
+			return opts, err
+		}
+		opts.template = t
+	}
+
+	if len(view) > 0 {
+		i := extraRowByLabel(view)
+		if i < 0 {
+			return opts, fmt.Errorf("unknown view %q", view)
+		}
+		row := extraRows[i].row
+		opts.to = []output{{custom: func(v uint64) string { return row.Render(v, width) }}}
+		return opts, nil
+	}
+
+	for _, name := range strings.Split(to, ",") {
+		name = strings.TrimSpace(name)
+		if f, ok := formatters[name]; ok {
+			opts.to = append(opts.to, output{custom: f})
+			continue
+		}
+
+		r, err := parseRadix(name)
+		if err != nil {
+			return opts, err
+		}
+		opts.to = append(opts.to, output{radix: r})
+	}
+
+	return opts, nil
+}
+
+// converter returns a Converter with the options' input settings, writing
+// to radix to.
+func (o cliOptions) converter(to radix) Converter {
+	return Converter{From: o.from, Detect: o.detect, To: to, Width: o.width, Signed: o.signed, Pad: o.pad}
+}
+
+func (o cliOptions) parse(s string) (uint64, error) {
+	if strings.HasPrefix(s, "-") && !o.signed {
+		return 0, fmt.Errorf("%s: negative values require -signed", s)
+	}
+	return o.converter(Decimal).Parse(s)
+}
+
+// runCLI converts each argument to every output base and prints the
+// results, one per line. Without arguments, the lines of file are
+// converted instead, or those of standard input if file is empty or "-".
+func runCLI(args []string, file string, opts cliOptions) error {
+	if len(args) == 0 {
+		if len(file) == 0 || file == "-" {
+			return runBatch(os.Stdin, opts)
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return runBatch(f, opts)
+	}
+
+	for _, arg := range args {
+		if err := convertOne(arg, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runBatch converts each non-empty line read from r. Lines that fail are
+// reported on stderr without stopping the batch, and an error is returned
+// once all lines are processed.
+func runBatch(r io.Reader, opts cliOptions) error {
+	failed := 0
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if len(text) == 0 {
+			continue
+		}
+
+		if err := convertOne(text, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "line %d: %v\n", line, err)
+			failed++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d line(s) failed to convert", failed)
+	}
+	return nil
+}
+
+// runRange converts every value of the inclusive range spec, like "0-15",
+// in order.
+func runRange(spec string, opts cliOptions) error {
+	low, high, err := opts.parseRange(spec)
+	if err != nil {
+		return err
+	}
+
+	for v := low; ; v = opts.next(v) {
+		if err := opts.emit(v); err != nil {
+			return err
+		}
+		if v == high {
+			return nil
+		}
+	}
+}
+
+// parseRange parses an inclusive range like "0-15" into its bounds. With
+// -signed the bounds may be negative, like "-8-7" or "-8--1".
+func (o cliOptions) parseRange(spec string) (uint64, uint64, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "-") && !o.signed {
+		return 0, 0, fmt.Errorf("invalid range %q, negative bounds require -signed", spec)
+	}
+
+	// Skip the sign of a negative low bound to find the separator.
+	start := 0
+	if strings.HasPrefix(spec, "-") {
+		start = 1
+	}
+	i := strings.Index(spec[start:], "-")
+	if i < 0 {
+		return 0, 0, fmt.Errorf("invalid range %q, expected low-high", spec)
+	}
+	lowStr := strings.TrimSpace(spec[:start+i])
+	highStr := strings.TrimSpace(spec[start+i+1:])
+	if len(lowStr) == 0 || len(highStr) == 0 {
+		return 0, 0, fmt.Errorf("invalid range %q, expected low-high", spec)
+	}
+
+	low, err := o.parse(lowStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	high, err := o.parse(highStr)
+	if err != nil {
+		return 0, 0, err
+	}
+	if o.signed && signedValue(low, o.width) > signedValue(high, o.width) || !o.signed && low > high {
+		return 0, 0, fmt.Errorf("invalid range %q, low is greater than high", spec)
+	}
+	return low, high, nil
+}
+
+// next returns the value after v in a range, wrapping around at the width
+// so that a signed range runs from its negative bound up through zero.
+func (o cliOptions) next(v uint64) uint64 {
+	return (v + 1) & mask(o.width)
+}
+
+// genTable writes the conversions of every value of the range spec to the
+// file at path, or standard output if path is empty: a line per value as
+// -range prints them, or a C array if carray is set.
+func genTable(spec, path string, carray bool, opts cliOptions) error {
+	if len(spec) == 0 {
+		return fmt.Errorf("-gentable needs a -range")
+	}
+	write := runRange
+	if carray {
+		write = writeCArray
+	}
+	if len(path) == 0 {
+		return write(spec, opts)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	opts.out = w
+	if err := write(spec, opts); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeCArray writes every value of the inclusive range spec converted to
+// the single output base as a C array of strings, indexed from the range's
+// low end.
+func writeCArray(spec string, opts cliOptions) error {
+	low, high, err := opts.parseRange(spec)
+	if err != nil {
+		return err
+	}
+	if len(opts.to) != 1 {
+		return fmt.Errorf("a C array needs a single -to base, got %d", len(opts.to))
+	}
+
+	fmt.Fprintf(opts.out, "/* %s */\n", spec)
+	fmt.Fprintf(opts.out, "static const char *const table[%d] = {\n", (high-low)&mask(opts.width)+1)
+	for v := low; ; v = opts.next(v) {
+		if opts.hasVia {
+			if _, err := opts.roundTrip(v); err != nil {
+				return err
+			}
+		}
+		fmt.Fprintf(opts.out, "\t%q,\n", opts.outputs(v)[0])
+		if v == high {
+			break
+		}
+	}
+	_, err = fmt.Fprintln(opts.out, "};")
+	return err
+}
+
+func convertOne(s string, opts cliOptions) error {
+	if strings.Contains(s, ".") {
+		return opts.convertFraction(s)
+	}
+
+	v, err := opts.parse(s)
+	if err != nil {
+		return err
+	}
+
+	if err := opts.emit(v); err != nil {
+		return fmt.Errorf("%s: %w", s, err)
+	}
+	return nil
+}
+
+// emit prints v in each output base, after checking its round trip through
+// the via base if one is set.
+func (o cliOptions) emit(v uint64) error {
+	if o.hasVia {
+		var err error
+		if v, err = o.roundTrip(v); err != nil {
+			return err
+		}
+	}
+	return o.print(v)
+}
+
+// roundTrip converts v to the via base and back, returning an error if the
+// result differs from v.
+func (o cliOptions) roundTrip(v uint64) (uint64, error) {
+	digits := formatValue(v, o.via)
+	back, err := parse(digits, o.via)
+	if err != nil {
+		return 0, fmt.Errorf("round trip via %s: %w", formatMode(o.via), err)
+	}
+	if back != v {
+		return 0, fmt.Errorf("round trip via %s: %d became %q, read back as %d", formatMode(o.via), v, digits, back)
+	}
+	return back, nil
+}
+
+// print prints v in each output base, one per line, or with the template
+// if one is set.
+func (o cliOptions) print(v uint64) error {
+	if o.template != nil {
+		r := result{
+			Value: v,
+			Bin:   o.format(v, Binary),
+			Oct:   o.format(v, Octal),
+			Dec:   o.format(v, Decimal),
+			Hex:   o.format(v, Hexadecimal),
+		}
+		if err := o.template.Execute(o.out, r); err != nil {
+			return err
+		}
+		fmt.Fprintln(o.out)
+		return nil
+	}
+
+	for _, s := range o.outputs(v) {
+		fmt.Fprintln(o.out, s)
+	}
+	return nil
+}
+
+// outputs returns v in each output base, with the unit if one is set.
+func (o cliOptions) outputs(v uint64) []string {
+	out := make([]string, len(o.to))
+	for i, to := range o.to {
+		if to.custom != nil {
+			out[i] = to.custom(v)
+		} else {
+			out[i] = o.format(v, to.radix)
+		}
+		if len(o.unit) > 0 {
+			out[i] += " " + o.unit
+		}
+	}
+	return out
+}
+
+// format returns v in radix r. With signed set, decimal output reads v as
+// two's complement at the width.
+func (o cliOptions) format(v uint64, r radix) string {
+	return o.converter(r).Format(v)
+}
+
+// isPipe reports whether f is not connected to a terminal.
+func isPipe(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
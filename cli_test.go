@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		spec      string
+		signed    bool
+		low, high uint64
+		ok        bool
+	}{
+		{"0-15", false, 0, 15, true},
+		{" 2 - 4 ", false, 2, 4, true},
+		{"-3", false, 0, 0, false},
+		{"3-", false, 0, 0, false},
+		{"-", false, 0, 0, false},
+		{"-3-2", false, 0, 0, false},
+		{"4-2", false, 0, 0, false},
+		{"-3", true, 0, 0, false},
+		{"-3-2", true, 0xFD, 2, true},
+		{"-3--1", true, 0xFD, 0xFF, true},
+		{"2--1", true, 0, 0, false},
+	}
+	for _, tt := range tests {
+		opts := cliOptions{from: Decimal, width: 8, signed: tt.signed}
+		low, high, err := opts.parseRange(tt.spec)
+		if (err == nil) != tt.ok || tt.ok && (low != tt.low || high != tt.high) {
+			t.Errorf("signed %t range %q = %#x, %#x, %v, want %#x, %#x, ok %t",
+				tt.signed, tt.spec, low, high, err, tt.low, tt.high, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type copiedMsg string
+
+type clipboardMsg string
+
+// readClipboard returns a command reading the system clipboard.
+func readClipboard() tea.Cmd {
+	return func() tea.Msg {
+		s, err := clipboard.ReadAll()
+		if err != nil {
+			return errMsg{err.Error()}
+		}
+		return clipboardMsg(s)
+	}
+}
+
+type alphabetMsg string
+
+// readAlphabet returns a command reading the system clipboard as a digit
+// alphabet.
+func readAlphabet() tea.Cmd {
+	return func() tea.Msg {
+		msg := readClipboard()()
+		if s, ok := msg.(clipboardMsg); ok {
+			return alphabetMsg(s)
+		}
+		return msg
+	}
+}
+
+type diffTargetMsg string
+
+// readDiffTarget returns a command reading the system clipboard as a value
+// to compare with.
+func readDiffTarget() tea.Cmd {
+	return func() tea.Msg {
+		msg := readClipboard()()
+		if s, ok := msg.(clipboardMsg); ok {
+			return diffTargetMsg(s)
+		}
+		return msg
+	}
+}
+
+// clipboardDiffView compares the value with the one read from the
+// clipboard, bitwise and numerically.
+func (m model) clipboardDiffView() string {
+	v := m.value()
+	diff := fmt.Sprintf("+%d", v-m.clipValue)
+	if v < m.clipValue {
+		diff = fmt.Sprintf("-%d", m.clipValue-v)
+	}
+	return "\n" + bitDiff("value", v, "clipboard", m.clipValue) + "difference: " + diff + "\n"
+}
+
+// clipboardPreview shows how the clipboard would be parsed before it is
+// pasted.
+type clipboardPreview struct {
+	text  string
+	value uint64
+	radix radix
+	err   error
+}
+
+func (m model) previewClipboard(text string) *clipboardPreview {
+	p := &clipboardPreview{text: text}
+	p.value, p.radix, p.err = parseLiteral(m.ungroup(text), m.mode)
+	return p
+}
+
+func (p clipboardPreview) View() string {
+	text := p.text
+	if len(text) > 40 {
+		text = text[:40] + "…"
+	}
+
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf("clipboard: %s\n", strconv.Quote(text)))
+	if p.err != nil {
+		b.WriteString(fmt.Sprintf("error: %v\n", p.err))
+		b.WriteString("esc to close\n")
+	} else {
+		b.WriteString(fmt.Sprintf("detected: %s\n", formatMode(p.radix)))
+		b.WriteString(fmt.Sprintf("value: %d\n", p.value))
+		b.WriteString("enter to paste, esc to cancel\n")
+	}
+	return b.String()
+}
+
+// copyToClipboard returns a command writing s to the system clipboard.
+func copyToClipboard(s string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(s); err != nil {
+			return errMsg{err.Error()}
+		}
+		return copiedMsg(s)
+	}
+}
+
+// binaryLiteral returns v as a Go binary literal with nibbles separated by
+// underscores, like 0b1111_0000.
+func binaryLiteral(v uint64) string {
+	s := format(v, Binary)
+	if len(s) == 0 {
+		s = "0"
+	}
+	return "0b" + group(s, groupSize(Binary), "_")
+}
+
+// rowsJSON returns a JSON object of every shown row keyed by its label,
+// like {"bin":"11111111","dec":"255",...}.
+func (m model) rowsJSON() string {
+	rows := map[string]string{}
+	for r := Binary; r <= Hexadecimal; r++ {
+		rows[formatMode(r)] = formatValue(m.value(), r)
+	}
+	for i, row := range extraRows {
+		if m.rowShown(i) {
+			rows[row.row.Label()] = m.renderRow(row.row)
+		}
+	}
+
+	data, _ := json.Marshal(rows)
+	return string(data)
+}
+
+// decHex returns v in decimal followed by v as a hex literal, like "255 0xFF".
+func decHex(v uint64) string {
+	return fmt.Sprintf("%d 0x%s", v, formatValue(v, Hexadecimal))
+}
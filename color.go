@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// colorRow is a Row of a color, which the interface shows with a swatch in
+// terminals that support true color. Render returns plain text, so that
+// copied rows carry no escape sequences.
+type colorRow struct {
+	funcRow
+	color func(v uint64) (string, bool)
+}
+
+// Swatch returns the "#RRGGBB" color to show a swatch of, and false if the
+// value is not a color.
+func (r colorRow) Swatch(v uint64) (string, bool) {
+	return r.color(v)
+}
+
+// swatchBlock returns a block of the color hex, like "#FF8000".
+func swatchBlock(hex string) string {
+	return lipgloss.NewStyle().Background(lipgloss.Color(hex)).Render("      ")
+}
+
+// rgbColor reads the value as a 24-bit RGB color.
+func rgbColor(v uint64) (string, bool) {
+	if v > 0xFFFFFF {
+		return "", false
+	}
+	return fmt.Sprintf("#%06X", v), true
+}
+
+// swatch shows the value as a 24-bit RGB color.
+func swatch(v uint64) string {
+	hex, ok := rgbColor(v)
+	if !ok {
+		return "does not fit in 24 bits"
+	}
+	return hex
+}
+
+// rgbaColor reads the value as a 32-bit RGBA color blended over black by its
+// alpha.
+func rgbaColor(v uint64) (string, bool) {
+	if v > 0xFFFFFFFF {
+		return "", false
+	}
+	r, g, b, a := v>>24, v>>16&0xFF, v>>8&0xFF, v&0xFF
+	return fmt.Sprintf("#%02X%02X%02X", r*a/255, g*a/255, b*a/255), true
+}
+
+// rgba shows the value as a 32-bit RGBA color's channels.
+func rgba(v uint64) string {
+	if v > 0xFFFFFFFF {
+		return "does not fit in 32 bits"
+	}
+
+	r, g, b, a := v>>24, v>>16&0xFF, v>>8&0xFF, v&0xFF
+	return fmt.Sprintf("R=%d G=%d B=%d A=%d (%d%% opaque)", r, g, b, a, a*100/255)
+}
+
+func init() {
+	RegisterRow("*", colorRow{funcRow{"color", swatch}, rgbColor})
+	RegisterRow(",", colorRow{funcRow{"rgba", rgba}, rgbaColor})
+}
@@ -0,0 +1,29 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+var compactStyle = lipgloss.NewStyle().Bold(true).Reverse(true)
+
+// compactOrder is the order radixes are preferred in when their
+// representations are equally short.
+var compactOrder = []radix{Decimal, Hexadecimal, Octal, Binary}
+
+// compactRadix returns the radix with the shortest representation of v.
+func compactRadix(v uint64) radix {
+	best := compactOrder[0]
+	for _, r := range compactOrder[1:] {
+		if len(formatValue(v, r)) < len(formatValue(v, best)) {
+			best = r
+		}
+	}
+	return best
+}
+
+// rowLabel returns the label of radix r's row, emphasized if it is the most
+// compact for the value and emphasizeCompact is set.
+func (m model) rowLabel(r radix) string {
+	if m.emphasizeCompact && r == compactRadix(m.value()) {
+		return compactStyle.Render(formatMode(r))
+	}
+	return formatMode(r)
+}
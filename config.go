@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type fieldSpec struct {
+	Name string `json:"name"`
+	Bits string `json:"bits"`
+	low  int
+	high int
+}
+
+type flagSpec struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	bit   uint64
+}
+
+// constant is a named value the current value is compared against.
+type constant struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	value uint64
+}
+
+type config struct {
+	Fields     []fieldSpec `json:"fields"`
+	Flags      []flagSpec  `json:"flags"`
+	Constants  []constant  `json:"constants"`
+	Macros     []macro     `json:"macros"`
+	Separator  string      `json:"separator"`
+	Persist    bool        `json:"persist"`
+	Kiosk      int         `json:"kiosk"`
+	Idle       int         `json:"idle"`
+	Autosave   int         `json:"autosave"`
+	NoTitle    bool        `json:"noTitle"`
+	Theme      string      `json:"theme"`
+	AsmSyntax  string      `json:"asmSyntax"`
+	Send       string      `json:"send"`
+	MixedRadix []uint64    `json:"mixedRadix"`
+	Recent     []int       `json:"recentBases"`
+	Version    []string    `json:"version"`
+	version    []fieldSpec
+	Settings   settings `json:"settings"`
+}
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "conv", "config.json"), nil
+}
+
+// loadConfig reads the user's config file. A missing file is not an error
+// and yields the default configuration.
+func loadConfig() (config, error) {
+	cfg := config{Separator: defaultSeparator}
+
+	path, err := configPath()
+	if err != nil {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	} else if err != nil {
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if w := cfg.Settings.Width; w < 0 || w > 64 {
+		return cfg, fmt.Errorf("%s: width %d is out of 1-64", path, w)
+	}
+
+	for _, r := range cfg.MixedRadix {
+		if r < 2 {
+			return cfg, fmt.Errorf("%s: mixed radix %d is less than 2", path, r)
+		}
+	}
+
+	for _, base := range cfg.Recent {
+		if base < 2 || base > 36 {
+			return cfg, fmt.Errorf("%s: recent base %d is out of 2-36", path, base)
+		}
+	}
+
+	for i := range cfg.Fields {
+		f := &cfg.Fields[i]
+		f.low, f.high, err = parseBitRange(f.Bits)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: field %q: %w", path, f.Name, err)
+		}
+	}
+
+	for _, bits := range cfg.Version {
+		low, high, err := parseBitRange(bits)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: version: %w", path, err)
+		}
+		cfg.version = append(cfg.version, fieldSpec{Bits: bits, low: low, high: high})
+	}
+
+	for i := range cfg.Flags {
+		f := &cfg.Flags[i]
+		f.bit, err = strconv.ParseUint(f.Value, 0, 64)
+		if err != nil || f.bit == 0 || f.bit&(f.bit-1) != 0 {
+			return cfg, fmt.Errorf("%s: flag %q: value %q is not a single bit", path, f.Name, f.Value)
+		}
+	}
+
+	for i := range cfg.Constants {
+		c := &cfg.Constants[i]
+		c.value, _, err = parseLiteral(c.Value, Decimal)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: constant %q: invalid value %q", path, c.Name, c.Value)
+		}
+	}
+
+	for i := range cfg.Macros {
+		mc := &cfg.Macros[i]
+		for _, s := range mc.Steps {
+			step, err := parseMacroStep(s)
+			if err != nil {
+				return cfg, fmt.Errorf("%s: macro %q: %w", path, mc.Name, err)
+			}
+			mc.steps = append(mc.steps, step)
+		}
+	}
+
+	return cfg, nil
+}
+
+// parseBitRange parses a bit range like "4-7" or a single bit like "3".
+func parseBitRange(s string) (int, int, error) {
+	lowStr, highStr, found := strings.Cut(s, "-")
+	if !found {
+		highStr = lowStr
+	}
+
+	low, err := strconv.Atoi(strings.TrimSpace(lowStr))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid bit range %q", s)
+	}
+	high, err := strconv.Atoi(strings.TrimSpace(highStr))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid bit range %q", s)
+	}
+
+	if low > high {
+		low, high = high, low
+	}
+	if low < 0 || high > 63 {
+		return 0, 0, fmt.Errorf("bit range %q is out of 0-63", s)
+	}
+
+	return low, high, nil
+}
+
+// extract returns the value of the field's bits in v.
+func (f fieldSpec) extract(v uint64) uint64 {
+	width := f.high - f.low + 1
+	if width == 64 {
+		return v
+	}
+	return (v >> f.low) & (1<<width - 1)
+}
+
+// decodeFlags returns the names of the flags set in v joined by "|". Set
+// bits without a name are appended in hexadecimal.
+func decodeFlags(flags []flagSpec, v uint64) string {
+	var names []string
+	for _, f := range flags {
+		if v&f.bit != 0 {
+			names = append(names, f.Name)
+			v &^= f.bit
+		}
+	}
+
+	if v != 0 {
+		names = append(names, "0x"+format(v, Hexadecimal))
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+// nearestConstant returns the constant closest to v, like "= INT32_MAX" if
+// v is one or "INT32_MAX + 1" if it is near one.
+func nearestConstant(constants []constant, v uint64) string {
+	best, dist := constants[0], uint64(math.MaxUint64)
+	for _, c := range constants {
+		d := max(c.value, v) - min(c.value, v)
+		if d < dist {
+			best, dist = c, d
+		}
+	}
+
+	switch {
+	case dist == 0:
+		return "= " + best.Name
+	case v > best.value:
+		return fmt.Sprintf("%s + %d", best.Name, dist)
+	default:
+		return fmt.Sprintf("%s - %d", best.Name, dist)
+	}
+}
+
+// decodeMixedRadix splits v into digits of the given radices, most
+// significant first, and joins them with colons, like "1:01:01" for 3661
+// with radices 24, 60, 60. Digits are padded to the width of their radix's
+// largest digit, and any overflow of the first radix is prepended.
+func decodeMixedRadix(radices []uint64, v uint64) string {
+	parts := make([]string, len(radices))
+	for i := len(radices) - 1; i >= 0; i-- {
+		r := radices[i]
+		width := len(strconv.FormatUint(r-1, 10))
+		parts[i] = fmt.Sprintf("%0*d", width, v%r)
+		v /= r
+	}
+
+	if v > 0 {
+		parts = append([]string{strconv.FormatUint(v, 10)}, parts...)
+	} else {
+		parts[0] = strings.TrimLeft(parts[0], "0")
+		if len(parts[0]) == 0 {
+			parts[0] = "0"
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// decodeVersion returns the version packed in v, with the parts extracted
+// from the bit ranges of parts joined by dots, like "1.0.1".
+func decodeVersion(parts []fieldSpec, v uint64) string {
+	out := make([]string, len(parts))
+	for i, p := range parts {
+		out[i] = strconv.FormatUint(p.extract(v), 10)
+	}
+	return strings.Join(out, ".")
+}
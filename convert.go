@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// base returns the numeric base of the radix.
+func (r radix) base() int {
+	switch r {
+	case Binary:
+		return 2
+	case Octal:
+		return 8
+	case Decimal:
+		return 10
+	case Hexadecimal:
+		return 16
+	}
+
+	return 10
+}
+
+// parse parses s as an unsigned number in radix r. An empty string is zero.
+func parse(s string, r radix) (uint64, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+
+	return strconv.ParseUint(s, r.base(), 64)
+}
+
+// format returns the representation of v in radix r, using upper-case
+// hexadecimal digits. Zero is represented by an empty string, matching an
+// empty input.
+func format(v uint64, r radix) string {
+	if v == 0 {
+		return ""
+	}
+
+	return strings.ToUpper(strconv.FormatUint(v, r.base()))
+}
+
+// formatValue is like format, but represents zero as "0".
+func formatValue(v uint64, r radix) string {
+	if v == 0 {
+		return "0"
+	}
+	return format(v, r)
+}
+
+// formatAll returns the representation of v in every radix.
+func formatAll(v uint64) [4]string {
+	var out [4]string
+	for r := Binary; r <= Hexadecimal; r++ {
+		out[r] = format(v, r)
+	}
+	return out
+}
+
+// formatAllValues is like formatAll, but represents zero as "0".
+func formatAllValues(v uint64) [4]string {
+	var out [4]string
+	for r := Binary; r <= Hexadecimal; r++ {
+		out[r] = formatValue(v, r)
+	}
+	return out
+}
+
+// encodeAlphabet returns v in the base of the alphabet's length, using its
+// runes as the digits from zero up.
+func encodeAlphabet(v uint64, alphabet []rune) string {
+	base := uint64(len(alphabet))
+	digits := []rune{alphabet[v%base]}
+	for v /= base; v > 0; v /= base {
+		digits = append(digits, alphabet[v%base])
+	}
+
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// parseAlphabet checks that s, with surrounding space removed, is a usable
+// digit alphabet: at least two distinct runes.
+func parseAlphabet(s string) ([]rune, error) {
+	alphabet := []rune(strings.TrimSpace(s))
+	if len(alphabet) < 2 {
+		return nil, fmt.Errorf("an alphabet needs at least 2 digits, got %d", len(alphabet))
+	}
+
+	seen := map[rune]bool{}
+	for _, c := range alphabet {
+		if seen[c] {
+			return nil, fmt.Errorf("digit %q appears twice in the alphabet", c)
+		}
+		seen[c] = true
+	}
+	return alphabet, nil
+}
+
+// convert parses s in radix from and returns its representation in every
+// radix.
+func convert(s string, from radix) ([4]string, error) {
+	v, err := parse(s, from)
+	if err != nil {
+		return [4]string{}, err
+	}
+	return formatAll(v), nil
+}
+
+var literalPrefixes = []struct {
+	prefix string
+	radix  radix
+}{
+	{"0b", Binary},
+	{"0o", Octal},
+	{"0x", Hexadecimal},
+}
+
+// literal returns v in radix r with the prefix parseLiteral recognizes, like
+// 0xFF or 0o377. Decimal has no prefix. The result is also a valid Python
+// literal.
+func literal(v uint64, r radix) string {
+	return literalPrefix(r) + formatValue(v, r)
+}
+
+// literalPrefix returns the literal prefix of radix r, or "" for decimal.
+func literalPrefix(r radix) string {
+	for _, p := range literalPrefixes {
+		if p.radix == r {
+			return p.prefix
+		}
+	}
+	return ""
+}
+
+var literalSuffixes = map[byte]radix{
+	'b': Binary,
+	'o': Octal,
+	'd': Decimal,
+	'h': Hexadecimal,
+}
+
+// literalSuffix returns the assembly-style suffix of radix r, like "h".
+func literalSuffix(r radix) string {
+	for suffix, sr := range literalSuffixes {
+		if sr == r {
+			return string(suffix)
+		}
+	}
+	return ""
+}
+
+// parseLiteral parses a number written with an optional base prefix (0b,
+// 0o, 0x) or assembly-style suffix (b, o, d, h), falling back to radix def.
+// A prefix or suffix is only recognized if the remaining digits are valid in
+// its base, so "1010b" is binary while "12b" is left to def. A suffix that is
+// also a digit of def is read as that digit, so with a hexadecimal default
+// "1b" is 0x1B.
+func parseLiteral(s string, def radix) (uint64, radix, error) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+
+	for _, p := range literalPrefixes {
+		if rest, ok := strings.CutPrefix(lower, p.prefix); ok && len(rest) > 0 {
+			if v, err := parse(rest, p.radix); err == nil {
+				return v, p.radix, nil
+			}
+		}
+	}
+
+	if len(s) > 1 {
+		last := lower[len(lower)-1]
+		if r, ok := literalSuffixes[last]; ok && !isValidDigit(rune(last), def) {
+			if v, err := parse(s[:len(s)-1], r); err == nil {
+				return v, r, nil
+			}
+		}
+	}
+
+	v, err := parse(s, def)
+	return v, def, err
+}
+
+// parseRadix parses a base name such as "hex" or "16".
+func parseRadix(name string) (radix, error) {
+	for r := Binary; r <= Hexadecimal; r++ {
+		if name == formatMode(r) || name == strconv.Itoa(r.base()) {
+			return r, nil
+		}
+	}
+	return Decimal, fmt.Errorf("unknown base %q", name)
+}
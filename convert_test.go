@@ -0,0 +1,111 @@
+package main
+
+import "testing"
+
+var benchValues = []struct {
+	name  string
+	value uint64
+}{
+	{"small", 42},
+	{"medium", 0xDEADBEEF},
+	{"large", 0xFFFFFFFFFFFFFFFF},
+}
+
+func BenchmarkFormatAll(b *testing.B) {
+	for _, bv := range benchValues {
+		b.Run(bv.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				formatAll(bv.value)
+			}
+		})
+	}
+}
+
+func BenchmarkConvert(b *testing.B) {
+	for _, bv := range benchValues {
+		for r := Binary; r <= Hexadecimal; r++ {
+			s := format(bv.value, r)
+			b.Run(bv.name+"/"+formatMode(r), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					if _, err := convert(s, r); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestConverterRejectsNoDigits(t *testing.T) {
+	for _, s := range []string{"", " ", "-", "- "} {
+		for _, detect := range []bool{false, true} {
+			c := Converter{From: Decimal, Detect: detect, To: Hexadecimal, Signed: true}
+			if got, err := c.Convert(s); err == nil {
+				t.Errorf("converting %q with detect %t = %q, want an error", s, detect, got)
+			}
+		}
+	}
+}
+
+func TestParseLiteral(t *testing.T) {
+	tests := []struct {
+		in    string
+		def   radix
+		want  uint64
+		radix radix
+	}{
+		{"0xFF", Decimal, 0xFF, Hexadecimal},
+		{"1010b", Decimal, 10, Binary},
+		{"12b", Decimal, 0, Decimal},
+		{"17o", Decimal, 15, Octal},
+		{"FFh", Decimal, 0xFF, Hexadecimal},
+		{"10d", Decimal, 10, Decimal},
+		{"1b", Hexadecimal, 0x1B, Hexadecimal},
+		{"7d", Hexadecimal, 0x7D, Hexadecimal},
+		{"10d", Hexadecimal, 0x10D, Hexadecimal},
+		{"FFh", Hexadecimal, 0xFF, Hexadecimal},
+		{"0b101", Hexadecimal, 5, Binary},
+	}
+	for _, tt := range tests {
+		v, r, err := parseLiteral(tt.in, tt.def)
+		if tt.want == 0 {
+			if err == nil {
+				t.Errorf("parseLiteral(%q, %s) = %d, want an error", tt.in, formatMode(tt.def), v)
+			}
+			continue
+		}
+		if err != nil || v != tt.want || r != tt.radix {
+			t.Errorf("parseLiteral(%q, %s) = %#x, %s, %v, want %#x, %s",
+				tt.in, formatMode(tt.def), v, formatMode(r), err, tt.want, formatMode(tt.radix))
+		}
+	}
+}
+
+func FuzzConvert(f *testing.F) {
+	f.Add("255", uint8(Decimal), uint8(Hexadecimal), uint8(8), false)
+	f.Add("-1", uint8(Decimal), uint8(Binary), uint8(16), true)
+	f.Add("0xFF", uint8(Octal), uint8(Decimal), uint8(64), false)
+	f.Add("", uint8(Binary), uint8(Octal), uint8(0), false)
+
+	f.Fuzz(func(t *testing.T, s string, from, to, width uint8, signed bool) {
+		fr, tr := radix(from%4), radix(to%4)
+
+		if v, err := parse(s, fr); err == nil {
+			if back, err := parse(format(v, tr), tr); err != nil || back != v {
+				t.Fatalf("%q in %s: %d formats as %q in %s, which parses as %d, %v",
+					s, formatMode(fr), v, format(v, tr), formatMode(tr), back, err)
+			}
+		}
+
+		c := Converter{From: fr, Detect: true, To: tr, Width: int(width % 65), Signed: signed}
+		v, err := c.Parse(s)
+		if err != nil {
+			return
+		}
+		out := c.Format(v)
+		back, err := Converter{From: tr, To: tr, Width: c.Width, Signed: signed}.Parse(out)
+		if err != nil || back != v {
+			t.Fatalf("%q: %d converts to %q, which parses as %d, %v", s, v, out, back, err)
+		}
+	})
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Converter converts values between bases with settings given once, for
+// converting many values the same way.
+type Converter struct {
+	// From is the base of the input values. If Detect is set, the base is
+	// detected from each value's prefix or suffix, falling back to From.
+	From   radix
+	Detect bool
+
+	// To is the base of the output values.
+	To radix
+
+	// Width is the number of bits values must fit in, 64 if zero. If Signed
+	// is set, negative values are accepted and stored in two's complement,
+	// and decimal output is signed.
+	Width  int
+	Signed bool
+
+	// Lower uses lower-case hexadecimal digits, and Prefix adds the 0b, 0o
+	// or 0x prefix of the output base.
+	Lower  bool
+	Prefix bool
+
+	// Pad left-pads the digits with zeros to at least Pad characters, not
+	// counting a sign or prefix.
+	Pad int
+}
+
+func (c Converter) width() int {
+	if c.Width == 0 {
+		return 64
+	}
+	return c.Width
+}
+
+// Convert parses s and formats it in the output base.
+func (c Converter) Convert(s string) (string, error) {
+	v, err := c.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return c.Format(v), nil
+}
+
+// Parse parses s in the input base, checking that it fits in the width.
+func (c Converter) Parse(s string) (uint64, error) {
+	width := c.width()
+	if width < 1 || width > 64 {
+		return 0, fmt.Errorf("width %d is out of 1-64", width)
+	}
+
+	digits, negative := strings.CutPrefix(s, "-")
+	if negative && !c.Signed {
+		return 0, fmt.Errorf("%s: negative values are only accepted when signed", s)
+	}
+	if len(strings.TrimSpace(digits)) == 0 {
+		return 0, fmt.Errorf("%q has no digits", s)
+	}
+
+	var v uint64
+	var err error
+	if c.Detect {
+		v, _, err = parseLiteral(digits, c.From)
+	} else {
+		v, err = parse(digits, c.From)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if negative {
+		if v > 1<<(width-1) {
+			return 0, fmt.Errorf("%s does not fit in %d signed bits", s, width)
+		}
+		return twosComplement(v, width), nil
+	}
+
+	if v > mask(width) {
+		return 0, fmt.Errorf("%s does not fit in %d bits", s, width)
+	}
+	return v, nil
+}
+
+// Format returns v in the output base.
+func (c Converter) Format(v uint64) string {
+	if c.Signed && c.To == Decimal {
+		if n := signedValue(v, c.width()); n < 0 {
+			return "-" + c.pad(strconv.FormatUint(uint64(-n), 10))
+		}
+	}
+
+	s := c.pad(formatValue(v, c.To))
+	if c.Lower {
+		s = strings.ToLower(s)
+	}
+	if c.Prefix {
+		s = literalPrefix(c.To) + s
+	}
+	return s
+}
+
+func (c Converter) pad(digits string) string {
+	if n := c.Pad - len(digits); n > 0 {
+		return strings.Repeat("0", n) + digits
+	}
+	return digits
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"hash/crc32"
+	"strings"
+)
+
+// crc8 returns the CRC-8 of b with the polynomial 0x07 and no reflection,
+// as used by SMBus.
+func crc8(b []byte) uint8 {
+	var crc uint8
+	for _, c := range b {
+		crc ^= c
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// crc16 returns the CRC-16/CCITT-FALSE of b, with the polynomial 0x1021
+// and an initial value of 0xFFFF.
+func crc16(b []byte) uint16 {
+	crc := uint16(0xFFFF)
+	for _, c := range b {
+		crc ^= uint16(c) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// widthBytes returns the bytes of v at the current width, most significant
+// first.
+func (m model) widthBytes() []byte {
+	n := max(1, (m.width+7)/8)
+	v := m.value() & mask(m.width)
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte(v >> (8 * (n - 1 - i)))
+	}
+	return b
+}
+
+// checksumsView shows checksums of the value's bytes at the current width.
+func (m model) checksumsView() string {
+	data := m.widthBytes()
+
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf("\nchecksums of %d bytes:\n", len(data)))
+	b.WriteString(fmt.Sprintf("crc-8:        %02X\n", crc8(data)))
+	b.WriteString(fmt.Sprintf("crc-16/ccitt: %04X\n", crc16(data)))
+	b.WriteString(fmt.Sprintf("crc-32:       %08X\n", crc32.ChecksumIEEE(data)))
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// divStep is one step of converting a number by repeated division: the
+// dividend, and the quotient and remainder of dividing it by the base.
+type divStep struct {
+	dividend, quotient, remainder uint64
+}
+
+// division steps through converting a value to a base by repeated
+// division, one division at a time.
+type division struct {
+	value uint64
+	base  uint64
+	steps []divStep
+	shown int
+}
+
+func newDivision(v, base uint64) *division {
+	d := &division{value: v, base: base, shown: 1}
+	for {
+		step := divStep{dividend: v, quotient: v / base, remainder: v % base}
+		d.steps = append(d.steps, step)
+		if step.quotient == 0 {
+			return d
+		}
+		v = step.quotient
+	}
+}
+
+// startDivision opens the step-through of converting the value to the base
+// written in input.
+func (m *model) startDivision(input string) error {
+	base, err := strconv.ParseUint(strings.TrimSpace(input), 10, 64)
+	if err != nil || base < 2 || base > 36 {
+		return errMsg{fmt.Sprintf("invalid base %q, expected 2-36", input)}
+	}
+	m.division = newDivision(m.value(), base)
+	return nil
+}
+
+// Update shows the next or previous step, or reports that the step-through
+// is closed.
+func (d *division) Update(msg tea.KeyMsg) (closed bool) {
+	switch msg.String() {
+	case " ", "enter", "right", "l":
+		d.shown = min(d.shown+1, len(d.steps))
+	case "backspace", "left", "h":
+		d.shown = max(d.shown-1, 1)
+	case "esc", "q", "ctrl+c":
+		return true
+	}
+	return false
+}
+
+func (d division) View() string {
+	b := strings.Builder{}
+	b.WriteString(fmt.Sprintf("%d to base %d by repeated division:\n", d.value, d.base))
+
+	digits := []byte{}
+	for _, s := range d.steps[:d.shown] {
+		digit := strconv.FormatUint(s.remainder, int(d.base))
+		digits = append([]byte(strings.ToUpper(digit)), digits...)
+		b.WriteString(fmt.Sprintf("%d ÷ %d = %d remainder %s\n", s.dividend, d.base, s.quotient, strings.ToUpper(digit)))
+	}
+
+	if d.shown == len(d.steps) {
+		b.WriteString(fmt.Sprintf("the remainders read upwards: %s\n", digits))
+		b.WriteString("esc to close\n")
+	} else {
+		b.WriteString(fmt.Sprintf("digits so far: %s\n", digits))
+		b.WriteString(fmt.Sprintf("step %d of %d, space for the next, esc to close\n", d.shown, len(d.steps)))
+	}
+	return b.String()
+}
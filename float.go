@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+// ieeeRow shows the IEEE-754 float of the current width. Read as a number,
+// the value is encoded as a float; read as a bit pattern, its bits are
+// decoded as one.
+type ieeeRow struct{}
+
+func (ieeeRow) Label() string {
+	return "ieee754"
+}
+
+func (ieeeRow) Render(value uint64, width int) string {
+	switch width {
+	case 32:
+		return fmt.Sprintf("0x%08X", math.Float32bits(float32(value)))
+	case 64:
+		return fmt.Sprintf("0x%016X", math.Float64bits(float64(value)))
+	}
+	return fmt.Sprintf("no %d-bit float", width)
+}
+
+func (ieeeRow) RenderPattern(bits uint64, width int) string {
+	switch width {
+	case 32:
+		return strconv.FormatFloat(float64(math.Float32frombits(uint32(bits))), 'g', -1, 32)
+	case 64:
+		return strconv.FormatFloat(math.Float64frombits(bits), 'g', -1, 64)
+	}
+	return fmt.Sprintf("no %d-bit float", width)
+}
+
+// floatOrders are the byte orders the value can be decoded as a float in:
+// as typed, most significant byte first, or with its bytes reversed.
+var floatOrders = []string{"big-endian", "little-endian"}
+
+// nextFloatOrder returns the byte order following o, where 0 is off and i+1
+// is floatOrders[i], wrapping around to off.
+func nextFloatOrder(o int) int {
+	return (o + 1) % (len(floatOrders) + 1)
+}
+
+// floatView decodes the bytes of the current width in the byte order o as
+// an IEEE-754 float, like "float: 0.15625" for the 32-bit 3E200000.
+func (m model) floatView(o int) string {
+	v := m.value() & mask(m.width)
+	if floatOrders[o-1] == "little-endian" {
+		switch m.width {
+		case 32:
+			v = uint64(bits.ReverseBytes32(uint32(v)))
+		case 64:
+			v = bits.ReverseBytes64(v)
+		}
+	}
+	return fmt.Sprintf("\nfloat (%s): %s\n", floatOrders[o-1], ieeeRow{}.RenderPattern(v, m.width))
+}
+
+func init() {
+	RegisterRow("ctrl+f", ieeeRow{})
+}
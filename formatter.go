@@ -0,0 +1,23 @@
+package main
+
+import "fmt"
+
+// Formatter formats a value in a custom base, for representations strconv
+// can't produce.
+type Formatter func(value uint64) string
+
+var formatters = map[string]Formatter{}
+
+// RegisterFormatter makes f available as an output base called name, such
+// as with -to on the command line. It panics if name is empty, names one of
+// the built-in bases or is already registered, since the formatter would
+// then shadow that base or another formatter.
+func RegisterFormatter(name string, f Formatter) {
+	if _, err := parseRadix(name); err == nil || len(name) == 0 {
+		panic(fmt.Sprintf("RegisterFormatter: %q is a built-in base", name))
+	}
+	if _, ok := formatters[name]; ok {
+		panic(fmt.Sprintf("RegisterFormatter: %q is already registered", name))
+	}
+	formatters[name] = f
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+// roman returns v in Roman numerals, for values from 1 to 3999.
+func roman(v uint64) string {
+	numerals := []struct {
+		value  uint64
+		symbol string
+	}{
+		{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+		{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+	}
+
+	var b []byte
+	for _, n := range numerals {
+		for ; v >= n.value; v -= n.value {
+			b = append(b, n.symbol...)
+		}
+	}
+	return string(b)
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	RegisterFormatter("roman", roman)
+	defer delete(formatters, "roman")
+
+	opts, err := newCLIOptions("hex", "roman,dec", "", "", "", "", 64, 0, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := bytes.Buffer{}
+	opts.out = &out
+
+	if err := runCLI([]string{"7CA"}, "", opts); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "MCMXCIV\n1994\n"; got != want {
+		t.Errorf("hex 7CA to roman,dec = %q, want %q", got, want)
+	}
+}
+
+func TestRegisterFormatterRejectsNamesInUse(t *testing.T) {
+	RegisterFormatter("roman", roman)
+	defer delete(formatters, "roman")
+
+	for _, name := range []string{"", "hex", "dec", "16", "2", "roman"} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterFormatter(%q) didn't panic", name)
+				}
+			}()
+			RegisterFormatter(name, roman)
+		})
+	}
+}
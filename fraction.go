@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+)
+
+// rounding is how a fractional expansion cut short at a number of digits
+// is rounded.
+type rounding int
+
+const (
+	truncate rounding = iota
+	halfEven
+	halfUp
+)
+
+var roundingModes = map[string]rounding{
+	"truncate":  truncate,
+	"half-even": halfEven,
+	"half-up":   halfUp,
+}
+
+// parseRounding parses a rounding mode name such as "half-even".
+func parseRounding(name string) (rounding, error) {
+	mode, ok := roundingModes[name]
+	if !ok {
+		return truncate, fmt.Errorf("unknown rounding %q, expected truncate, half-even or half-up", name)
+	}
+	return mode, nil
+}
+
+// parseFraction parses s, digits in radix r with a radix point like "0.1",
+// into the exact number it stands for. s has no sign; the caller handles a
+// leading minus.
+func parseFraction(s string, r radix) (*big.Rat, error) {
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if len(intPart)+len(fracPart) == 0 {
+		return nil, fmt.Errorf("%q has no digits", s)
+	}
+	for _, c := range intPart + fracPart {
+		if !isValidDigit(unicode.ToLower(c), r) {
+			return nil, fmt.Errorf("%q is not a valid %s fraction", s, formatMode(r))
+		}
+	}
+
+	base := big.NewInt(int64(r.base()))
+	n, ok := new(big.Int).SetString(intPart+fracPart, r.base())
+	if !ok {
+		return nil, fmt.Errorf("%q is not a valid %s fraction", s, formatMode(r))
+	}
+	denom := new(big.Int).Exp(base, big.NewInt(int64(len(fracPart))), nil)
+	return new(big.Rat).SetFrac(n, denom), nil
+}
+
+// formatFraction returns x, which must not be negative, in radix r with at
+// most digits fractional digits, rounding the digits cut off by mode.
+// Trailing zeros are dropped, so 0.1 in 4 binary digits is 0.0001 when
+// truncated and 0.001 when rounded.
+func formatFraction(x *big.Rat, r radix, digits int, mode rounding) string {
+	base := big.NewInt(int64(r.base()))
+	scale := new(big.Int).Exp(base, big.NewInt(int64(digits)), nil)
+
+	scaled := new(big.Int).Mul(x.Num(), scale)
+	q, rem := new(big.Int).QuoRem(scaled, x.Denom(), new(big.Int))
+
+	// Compare the remainder to half the denominator to round.
+	half := new(big.Int).Lsh(rem, 1).Cmp(x.Denom())
+	switch {
+	case mode == halfUp && half >= 0,
+		mode == halfEven && (half > 0 || half == 0 && q.Bit(0) == 1):
+		q.Add(q, big.NewInt(1))
+	}
+
+	intPart, frac := new(big.Int).QuoRem(q, scale, new(big.Int))
+	s := strings.ToUpper(intPart.Text(r.base()))
+	if digits == 0 || frac.Sign() == 0 {
+		return s
+	}
+	fracDigits := strings.ToUpper(frac.Text(r.base()))
+	fracDigits = strings.Repeat("0", digits-len(fracDigits)) + fracDigits
+	return s + "." + strings.TrimRight(fracDigits, "0")
+}
+
+// convertFraction converts s, a value with a radix point, to every output
+// base with at most fracDigits fractional digits. As for integers, negative
+// values need -signed and the value must fit in -width bits; -pad pads the
+// integer digits.
+func (o cliOptions) convertFraction(s string) error {
+	if o.template != nil || o.hasVia {
+		return fmt.Errorf("%s: fractional values can't be used with -format or -via", s)
+	}
+
+	digits, negative := strings.CutPrefix(s, "-")
+	if negative && !o.signed {
+		return fmt.Errorf("%s: negative values require -signed", s)
+	}
+	from := o.from
+	if o.detect {
+		for _, p := range literalPrefixes {
+			if rest, ok := strings.CutPrefix(strings.ToLower(digits), p.prefix); ok {
+				digits, from = rest, p.radix
+				break
+			}
+		}
+	}
+
+	x, err := parseFraction(digits, from)
+	if err != nil {
+		return err
+	}
+	if err := o.checkFractionWidth(s, x, negative); err != nil {
+		return err
+	}
+
+	for _, to := range o.to {
+		if to.custom != nil {
+			return fmt.Errorf("%s: fractional values can only be converted to bin, oct, dec or hex", s)
+		}
+		out := formatFraction(x, to.radix, o.fracDigits, o.rounding)
+		intPart, fracPart, _ := strings.Cut(out, ".")
+		if n := o.pad - len(intPart); n > 0 {
+			intPart = strings.Repeat("0", n) + intPart
+		}
+		out = intPart
+		if len(fracPart) > 0 {
+			out += "." + fracPart
+		}
+		if negative && strings.Trim(out, "0.") != "" {
+			out = "-" + out
+		}
+		if len(o.unit) > 0 {
+			out += " " + o.unit
+		}
+		fmt.Fprintln(o.out, out)
+	}
+	return nil
+}
+
+// checkFractionWidth checks that x, negated if negative, fits in the width:
+// below 2^width, or with -signed from -2^(width-1) up to below 2^(width-1).
+func (o cliOptions) checkFractionWidth(s string, x *big.Rat, negative bool) error {
+	bits := o.width
+	if o.signed {
+		bits--
+	}
+	limit := new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+
+	if negative && x.Cmp(limit) > 0 || !negative && x.Cmp(limit) >= 0 {
+		if o.signed {
+			return fmt.Errorf("%s does not fit in %d signed bits", s, o.width)
+		}
+		return fmt.Errorf("%s does not fit in %d bits", s, o.width)
+	}
+	return nil
+}
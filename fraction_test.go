@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormatFractionRounding(t *testing.T) {
+	tests := []struct {
+		in     string
+		from   radix
+		to     radix
+		digits int
+		mode   rounding
+		want   string
+	}{
+		{"0.1", Decimal, Binary, 4, truncate, "0.0001"},
+		{"0.1", Decimal, Binary, 4, halfEven, "0.001"},
+		{"0.1", Decimal, Binary, 4, halfUp, "0.001"},
+		{"0.25", Decimal, Binary, 1, truncate, "0"},
+		{"0.25", Decimal, Binary, 1, halfEven, "0"},
+		{"0.25", Decimal, Binary, 1, halfUp, "0.1"},
+		{"0.75", Decimal, Binary, 1, halfEven, "1"},
+		{"255.75", Decimal, Hexadecimal, 4, truncate, "FF.C"},
+		{"0.8", Hexadecimal, Decimal, 2, truncate, "0.5"},
+	}
+	for _, tt := range tests {
+		x, err := parseFraction(tt.in, tt.from)
+		if err != nil {
+			t.Fatalf("parseFraction(%q): %v", tt.in, err)
+		}
+		if got := formatFraction(x, tt.to, tt.digits, tt.mode); got != tt.want {
+			t.Errorf("%s %s in %d %s digits = %q, want %q",
+				formatMode(tt.from), tt.in, tt.digits, formatMode(tt.to), got, tt.want)
+		}
+	}
+}
+
+func TestParseFractionRejectsSigns(t *testing.T) {
+	for _, s := range []string{"+1.5", "-1.5", "1.-5", "1.+5", "-+1.5", "1_0.5", "."} {
+		if x, err := parseFraction(s, Decimal); err == nil {
+			t.Errorf("parseFraction(%q) = %v, want an error", s, x)
+		}
+	}
+}
+
+func TestConvertFraction(t *testing.T) {
+	tests := []struct {
+		in     string
+		width  int
+		pad    int
+		signed bool
+		want   string
+	}{
+		{"10.5", 64, 0, false, "A.8\n"},
+		{"10.5", 64, 4, false, "000A.8\n"},
+		{"-10.5", 8, 4, true, "-000A.8\n"},
+		{"255.5", 8, 0, false, "FF.8\n"},
+		{"-128.0", 8, 0, true, "-80\n"},
+		{"-0.5", 64, 0, false, ""},
+		{"-+1.5", 64, 0, true, ""},
+		{"256.5", 8, 0, false, ""},
+		{"128.0", 8, 0, true, ""},
+		{"-128.5", 8, 0, true, ""},
+	}
+	for _, tt := range tests {
+		opts, err := newCLIOptions("dec", "hex", "", "", "", "", tt.width, tt.pad, tt.signed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts.fracDigits = 16
+		out := bytes.Buffer{}
+		opts.out = &out
+
+		err = opts.convertFraction(tt.in)
+		if len(tt.want) == 0 {
+			if err == nil {
+				t.Errorf("%d-bit %q with signed %t = %q, want an error", tt.width, tt.in, tt.signed, out.String())
+			}
+			continue
+		}
+		if err != nil || out.String() != tt.want {
+			t.Errorf("%d-bit %q padded to %d = %q, %v, want %q", tt.width, tt.in, tt.pad, out.String(), err, tt.want)
+		}
+	}
+}
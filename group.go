@@ -0,0 +1,141 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const defaultSeparator = ","
+
+// groupSize returns the number of digits in a group for radix r.
+func groupSize(r radix) int {
+	switch r {
+	case Binary, Hexadecimal:
+		return 4
+	}
+	return 3
+}
+
+// startsGroup reports whether the digit at position i of an n-digit number
+// starts a new group of size digits, counting from the right.
+func startsGroup(i, n, size int) bool {
+	return i > 0 && (n-i)%size == 0
+}
+
+// group inserts sep between groups of size digits of s, counting from the
+// right.
+func group(s string, size int, sep string) string {
+	b := strings.Builder{}
+	for i := 0; i < len(s); i++ {
+		if startsGroup(i, len(s), size) {
+			b.WriteString(sep)
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// groupSeparator returns the separator between digit groups of radix r.
+// Decimal uses the configured thousands separator, other bases a space.
+func (m model) groupSeparator(r radix) string {
+	if r == Decimal {
+		return m.separator
+	}
+	return " "
+}
+
+// ungroup removes digit group separators from s: underscores, spaces and
+// the configured thousands separator.
+func (m model) ungroup(s string) string {
+	s = strings.TrimSpace(s)
+	for _, sep := range []string{"_", " ", m.separator} {
+		if len(sep) > 0 {
+			s = strings.ReplaceAll(s, sep, "")
+		}
+	}
+	return s
+}
+
+// nibbleHexLine returns a line to show beneath the binary row with the hex
+// digit of each nibble under its first digit, or "" if the binary row is
+// not laid out as a single line. pad is the padding the row is aligned
+// with.
+func (m model) nibbleHexLine(pad int) string {
+	s := m.input[Binary]
+	if m.grid || m.bigDigits || len(s) == 0 {
+		return ""
+	}
+
+	c := len(s)
+	if m.mode == Binary {
+		c = m.cursorPos
+	}
+	start, end := m.digitWindow(len(s), c, groupSize(Binary), m.groupSeparator(Binary))
+
+	b := strings.Builder{}
+	b.WriteString(strings.Repeat(" ", len("bin: ")+pad))
+	if m.msbLabels {
+		b.WriteString("    ")
+	}
+	if start > 0 {
+		b.WriteString(" ")
+	}
+
+	for i := start; i < end; i++ {
+		first := i == 0 || startsGroup(i, len(s), 4)
+		if m.grouping && i > start && first {
+			b.WriteString(m.groupSeparator(Binary))
+		}
+		if first || i == start {
+			b.WriteString(formatValue(nibbleAt(s, i), Hexadecimal))
+		} else {
+			b.WriteByte(' ')
+		}
+	}
+	return strings.TrimRight(b.String(), " ") + "\n"
+}
+
+// alignRight returns the padding that right-aligns rows of the given
+// widths, so their last digits line up. Rows of negative width, laid out on
+// several lines, are left as they are.
+func alignRight(widths [4]int) [4]int {
+	widest := 0
+	for _, w := range widths {
+		widest = max(widest, w)
+	}
+
+	var pad [4]int
+	for i, w := range widths {
+		if w >= 0 {
+			pad[i] = widest - w
+		}
+	}
+	return pad
+}
+
+// digitWidths returns the widths of the rendered digits of each radix, not
+// counting a cursor after the last digit, or -1 for rows on several lines.
+func (m model) digitWidths(digits [4]string) [4]int {
+	var widths [4]int
+	for r := Binary; r <= Hexadecimal; r++ {
+		switch {
+		case m.bigDigits && r == m.mode, strings.Contains(digits[r], "\n"):
+			widths[r] = -1
+		default:
+			widths[r] = lipgloss.Width(digits[r])
+			if r == m.mode && m.cursorPos == len(m.input[r]) {
+				widths[r]--
+			}
+		}
+	}
+	return widths
+}
+
+// nibbleAt returns the value of the nibble of the binary digits s that the
+// digit at position i belongs to, counting nibbles from the right.
+func nibbleAt(s string, i int) uint64 {
+	shift := (len(s) - 1 - i) / 4 * 4
+	v, _ := parse(s, Binary)
+	return v >> shift & 0xF
+}
@@ -0,0 +1,62 @@
+package main
+
+type snapshot struct {
+	input     [4]string
+	cursorPos int
+}
+
+// history holds the undo and redo stacks of edits.
+type history struct {
+	undo []snapshot
+	redo []snapshot
+}
+
+func (m model) snapshot() snapshot {
+	return snapshot{input: m.input, cursorPos: m.cursorPos}
+}
+
+func (m *model) restore(s snapshot) {
+	m.input = s.input
+	m.raw = ""
+	m.updateCursor(s.cursorPos)
+}
+
+// record saves the current state before an edit and discards redo history.
+func (m *model) record() {
+	m.history.undo = append(m.history.undo, m.snapshot())
+	m.history.redo = nil
+}
+
+func (m *model) undo() bool {
+	if len(m.history.undo) == 0 {
+		return false
+	}
+
+	last := len(m.history.undo) - 1
+	m.history.redo = append(m.history.redo, m.snapshot())
+	m.restore(m.history.undo[last])
+	m.history.undo = m.history.undo[:last]
+	return true
+}
+
+func (m *model) redo() bool {
+	if len(m.history.redo) == 0 {
+		return false
+	}
+
+	last := len(m.history.redo) - 1
+	m.history.undo = append(m.history.undo, m.snapshot())
+	m.restore(m.history.redo[last])
+	m.history.redo = m.history.redo[:last]
+	return true
+}
+
+func (m *model) undoAll() {
+	for m.undo() {
+	}
+}
+
+func (m *model) redoAll() {
+	for m.redo() {
+	}
+}
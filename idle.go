@@ -0,0 +1,25 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type idleTickMsg time.Time
+
+func idleTick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return idleTickMsg(t)
+	})
+}
+
+// checkIdle quits if no key was pressed for the idle timeout, and waits
+// out the rest of it otherwise, so each key press starts it over.
+func (m *model) checkIdle(now time.Time) tea.Cmd {
+	left := m.idle - now.Sub(m.lastInput)
+	if left <= 0 {
+		return tea.Quit
+	}
+	return idleTick(left)
+}
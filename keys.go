@@ -0,0 +1,554 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyAction is what a key does when no prompt or preview is open, given
+// the key pressed. It returns a command to run, or nil.
+type keyAction func(m *model, key string) tea.Cmd
+
+// keyActions binds every key Update handles, other than digits and the
+// keys of extra rows, to its action.
+var keyActions = map[string]keyAction{
+	"ctrl+c": quit,
+	"q":      quit,
+	"esc": func(m *model, key string) tea.Cmd {
+		m.navigate = true
+		return nil
+	},
+	"left":  cursorLeft,
+	"h":     cursorLeft,
+	"right": cursorRight,
+	"l":     cursorRight,
+	"up":    focusUp,
+	"k":     focusUp,
+	"down":  focusDown,
+	"j":     focusDown,
+	"]": func(m *model, key string) tea.Cmd {
+		if m.mode == Binary {
+			m.updateCursor(nextSetBit(m.input[m.mode], m.cursorPos))
+		}
+		return nil
+	},
+	"[": func(m *model, key string) tea.Cmd {
+		if m.mode == Binary {
+			m.updateCursor(prevSetBit(m.input[m.mode], m.cursorPos))
+		}
+		return nil
+	},
+	"g": func(m *model, key string) tea.Cmd {
+		m.grouping = !m.grouping
+		return nil
+	},
+	"#": func(m *model, key string) tea.Cmd {
+		m.grid = !m.grid
+		return nil
+	},
+	"G": func(m *model, key string) tea.Cmd {
+		m.bigDigits = !m.bigDigits
+		return nil
+	},
+	"tab": func(m *model, key string) tea.Cmd {
+		m.focusSlot(m.slot + 1)
+		return nil
+	},
+	"shift+tab": func(m *model, key string) tea.Cmd {
+		m.focusSlot(m.slot - 1)
+		return nil
+	},
+	"ctrl+n": func(m *model, key string) tea.Cmd {
+		m.newSlot()
+		return nil
+	},
+	"ctrl+w": func(m *model, key string) tea.Cmd {
+		m.closeSlot()
+		return nil
+	},
+	"/": func(m *model, key string) tea.Cmd {
+		m.splitSlot()
+		return nil
+	},
+	"\\": func(m *model, key string) tea.Cmd {
+		if err := m.mergeSlots(); err != nil {
+			m.err = err
+		}
+		return nil
+	},
+	"alt+a": func(m *model, key string) tea.Cmd {
+		m.openPrompt("aggregate slots (sum, product, and, or, xor, min, max)", (*model).aggregate)
+		return nil
+	},
+	"~": func(m *model, key string) tea.Cmd {
+		m.showDiff = !m.showDiff
+		return nil
+	},
+	"J": func(m *model, key string) tea.Cmd {
+		if m.hasClipValue {
+			m.hasClipValue = false
+			return nil
+		}
+		return readDiffTarget()
+	},
+	"alt+r": func(m *model, key string) tea.Cmd {
+		if m.width%4 != 0 {
+			m.err = errMsg{fmt.Sprintf("can't reverse the nibbles of %d bits, which is not a multiple of 4", m.width)}
+		} else {
+			m.setValue(reverseNibbles(m.value(), m.width))
+		}
+		return nil
+	},
+	"|": func(m *model, key string) tea.Cmd {
+		v := m.value()
+		m.setValue(mirror(v, m.width))
+		if m.value() == v {
+			m.status = fmt.Sprintf("bit palindrome at %d bits", m.width)
+		} else {
+			m.status = fmt.Sprintf("not a bit palindrome at %d bits", m.width)
+		}
+		return nil
+	},
+	">": func(m *model, key string) tea.Cmd {
+		m.setValue(nextPowerOfTwo(m.value()))
+		return nil
+	},
+	"<": func(m *model, key string) tea.Cmd {
+		m.setValue(prevPowerOfTwo(m.value()))
+		return nil
+	},
+	"}": func(m *model, key string) tea.Cmd {
+		m.setValue(nextFibonacci(m.value()))
+		return nil
+	},
+	"{": func(m *model, key string) tea.Cmd {
+		m.setValue(prevFibonacci(m.value()))
+		return nil
+	},
+	"@": func(m *model, key string) tea.Cmd {
+		m.setValue(uint64(time.Now().Unix()))
+		return nil
+	},
+	")": func(m *model, key string) tea.Cmd {
+		m.stepMultiple(false)
+		return nil
+	},
+	"(": func(m *model, key string) tea.Cmd {
+		m.stepMultiple(true)
+		return nil
+	},
+	"alt+s": func(m *model, key string) tea.Cmd {
+		m.openPrompt("step", (*model).setStep)
+		return nil
+	},
+	"alt+z": func(m *model, key string) tea.Cmd {
+		m.openPrompt("rotate digits by", (*model).rotateFocused)
+		return nil
+	},
+	"alt+n": func(m *model, key string) tea.Cmd {
+		if v, err := reverseDecimal(m.value()); err != nil {
+			m.err = err
+		} else {
+			m.setValue(v)
+		}
+		return nil
+	},
+	"alt+d": func(m *model, key string) tea.Cmd {
+		if err := m.reinterpretAsDecimal(); err != nil {
+			m.err = err
+		}
+		return nil
+	},
+	"alt+i": func(m *model, key string) tea.Cmd {
+		m.openPrompt("byte", (*model).insertByte)
+		return nil
+	},
+	"alt+o": func(m *model, key string) tea.Cmd {
+		m.openPrompt("file offset [length] [le]", (*model).loadFromFile)
+		return nil
+	},
+	"M": func(m *model, key string) tea.Cmd {
+		m.openPrompt("mask", (*model).setMask)
+		return nil
+	},
+	"\"": func(m *model, key string) tea.Cmd {
+		m.openPrompt("ascii", (*model).enterASCII)
+		return nil
+	},
+	"t": func(m *model, key string) tea.Cmd {
+		m.showTrail = !m.showTrail
+		m.trail = nil
+		return nil
+	},
+	"w": func(m *model, key string) tea.Cmd {
+		m.width = nextWidth(m.width)
+		return nil
+	},
+	"alt+m": func(m *model, key string) tea.Cmd {
+		m.msbLabels = !m.msbLabels
+		return nil
+	},
+	"alt+e": func(m *model, key string) tea.Cmd {
+		m.nibbleHex = !m.nibbleHex
+		return nil
+	},
+	"alt+t": func(m *model, key string) tea.Cmd {
+		m.shadeNibbles = !m.shadeNibbles
+		return nil
+	},
+	"alt+y": func(m *model, key string) tea.Cmd {
+		m.showLegend = !m.showLegend
+		return nil
+	},
+	"o": setOneHot,
+	"O": setOneHot,
+	"+": func(m *model, key string) tea.Cmd {
+		m.showNeighbours = !m.showNeighbours
+		return nil
+	},
+	"alt+f": func(m *model, key string) tea.Cmd {
+		m.rightAlign = !m.rightAlign
+		return nil
+	},
+	"I": func(m *model, key string) tea.Cmd {
+		m.emphasizeCompact = !m.emphasizeCompact
+		return nil
+	},
+	"alt+u": func(m *model, key string) tea.Cmd {
+		m.summing = !m.summing
+		return nil
+	},
+	"alt+0": func(m *model, key string) tea.Cmd {
+		m.resetTotal()
+		return nil
+	},
+	"enter": func(m *model, key string) tea.Cmd {
+		if m.summing {
+			if err := m.addToTotal(); err != nil {
+				m.err = err
+			}
+		}
+		return nil
+	},
+	"alt+q": func(m *model, key string) tea.Cmd {
+		m.quietOverflow = !m.quietOverflow
+		return nil
+	},
+	"alt+c": func(m *model, key string) tea.Cmd {
+		m.setValue(saturate(m.value(), m.width))
+		return nil
+	},
+	"W": func(m *model, key string) tea.Cmd {
+		m.showWidths = !m.showWidths
+		return nil
+	},
+	"Y": func(m *model, key string) tea.Cmd {
+		m.checksums = !m.checksums
+		return nil
+	},
+	"S": func(m *model, key string) tea.Cmd {
+		m.dualSigned = !m.dualSigned
+		return nil
+	},
+	"`": func(m *model, key string) tea.Cmd {
+		m.negative = !m.negative
+		return nil
+	},
+	"Q": func(m *model, key string) tea.Cmd {
+		m.typedSign = !m.typedSign
+		return nil
+	},
+	"P": func(m *model, key string) tea.Cmd {
+		m.bitPattern = !m.bitPattern
+		return nil
+	},
+	"n": func(m *model, key string) tea.Cmd {
+		m.showRaw = !m.showRaw
+		return nil
+	},
+	"v": func(m *model, key string) tea.Cmd {
+		m.showValid = !m.showValid
+		return nil
+	},
+	"z": func(m *model, key string) tea.Cmd {
+		m.keepZeros = !m.keepZeros
+		if !m.keepZeros {
+			m.updateInput()
+			m.updateCursor(m.cursorPos)
+		}
+		return nil
+	},
+	"ctrl+b": func(m *model, key string) tea.Cmd {
+		if m.cursor.Mode() == cursor.CursorBlink {
+			return m.cursor.SetMode(cursor.CursorStatic)
+		}
+		return m.cursor.SetMode(cursor.CursorBlink)
+	},
+	"alt+b": func(m *model, key string) tea.Cmd {
+		return copyToClipboard(binaryLiteral(m.value()))
+	},
+	"ctrl+v": func(m *model, key string) tea.Cmd {
+		return readClipboard()
+	},
+	"alt+v": func(m *model, key string) tea.Cmd {
+		if m.alphabet != nil {
+			m.alphabet = nil
+			return nil
+		}
+		return readAlphabet()
+	},
+	"_": func(m *model, key string) tea.Cmd {
+		m.openPrompt("show in base (2-36)", (*model).setBase)
+		return nil
+	},
+	"-": func(m *model, key string) tea.Cmd {
+		m.cycleBase()
+		return nil
+	},
+	"alt+.": func(m *model, key string) tea.Cmd {
+		m.openBitPrompt()
+		return nil
+	},
+	"alt+,": func(m *model, key string) tea.Cmd {
+		m.relativeBits = !m.relativeBits
+		return nil
+	},
+	"alt+/": func(m *model, key string) tea.Cmd {
+		m.openPrompt("divide into base (2-36)", (*model).startDivision)
+		return nil
+	},
+	" ": func(m *model, key string) tea.Cmd {
+		if m.kiosk > 0 {
+			m.kioskPaused = !m.kioskPaused
+		}
+		return nil
+	},
+	"alt+;": func(m *model, key string) tea.Cmd {
+		m.box = !m.box
+		m.scrollBy(0)
+		return nil
+	},
+	"alt+=": func(m *model, key string) tea.Cmd {
+		m.floatOrder = nextFloatOrder(m.floatOrder)
+		return nil
+	},
+	"alt+g": func(m *model, key string) tea.Cmd {
+		m.angleUnit = nextAngleUnit(m.angleUnit)
+		return nil
+	},
+	"alt+'": func(m *model, key string) tea.Cmd {
+		return copyToClipboard(m.asmImmediate())
+	},
+	"alt+h": func(m *model, key string) tea.Cmd {
+		return copyToClipboard(formatValue(m.value(), Hexadecimal))
+	},
+	"alt+p": func(m *model, key string) tea.Cmd {
+		return copyToClipboard(literal(m.value(), m.mode))
+	},
+	"alt+j": func(m *model, key string) tea.Cmd {
+		return copyToClipboard(m.rowsJSON())
+	},
+	"alt+l": func(m *model, key string) tea.Cmd {
+		return copyToClipboard(withLuhn(m.value()))
+	},
+	"alt+x": func(m *model, key string) tea.Cmd {
+		return copyToClipboard(decHex(m.value()))
+	},
+	"s": func(m *model, key string) tea.Cmd {
+		m.register = m.value()
+		m.hasRegister = true
+		return nil
+	},
+	"p": func(m *model, key string) tea.Cmd {
+		if m.hasRegister {
+			m.setValue(m.register)
+		}
+		return nil
+	},
+	"alt+-": func(m *model, key string) tea.Cmd {
+		if len(m.macros) == 0 {
+			m.err = errMsg{"no macros in the config"}
+		} else {
+			m.openPrompt("macro ("+m.macroNames()+")", (*model).runMacro)
+		}
+		return nil
+	},
+	"alt+k": func(m *model, key string) tea.Cmd {
+		m.openPrompt("store as", (*model).storeNamed)
+		return nil
+	},
+	"alt+1": recallKey,
+	"alt+2": recallKey,
+	"alt+3": recallKey,
+	"alt+4": recallKey,
+	"alt+5": recallKey,
+	"alt+6": recallKey,
+	"alt+7": recallKey,
+	"alt+8": recallKey,
+	"alt+9": recallKey,
+	"alt+w": func(m *model, key string) tea.Cmd {
+		m.anchorAfter = !m.anchorAfter
+		return nil
+	},
+	"pgdown": func(m *model, key string) tea.Cmd {
+		m.scrollBy(m.pageHeight())
+		return nil
+	},
+	"pgup": func(m *model, key string) tea.Cmd {
+		m.scrollBy(-m.pageHeight())
+		return nil
+	},
+	"ctrl+d": func(m *model, key string) tea.Cmd {
+		m.scrollBy(m.pageHeight() / 2)
+		return nil
+	},
+	"ctrl+u": func(m *model, key string) tea.Cmd {
+		m.scrollBy(-m.pageHeight() / 2)
+		return nil
+	},
+	"ctrl+s": func(m *model, key string) tea.Cmd {
+		if err := m.exportSettings(); err != nil {
+			m.err = err
+		}
+		return nil
+	},
+	"ctrl+l": func(m *model, key string) tea.Cmd {
+		m.resync()
+		return nil
+	},
+	"u": func(m *model, key string) tea.Cmd {
+		m.undo()
+		return nil
+	},
+	"ctrl+r": func(m *model, key string) tea.Cmd {
+		m.redo()
+		return nil
+	},
+	"U": func(m *model, key string) tea.Cmd {
+		m.undoAll()
+		return nil
+	},
+	"R": func(m *model, key string) tea.Cmd {
+		m.redoAll()
+		return nil
+	},
+	"x": func(m *model, key string) tea.Cmd {
+		m.zeroDigit()
+		return nil
+	},
+	"^": func(m *model, key string) tea.Cmd {
+		m.insertDigit(maxDigit(m.mode))
+		return nil
+	},
+	"backspace": func(m *model, key string) tea.Cmd {
+		if m.cursorPos > 0 {
+			m.record()
+			newPos := m.cursorPos - 1
+			newInput := m.input[m.mode][:newPos]
+			if m.cursorPos < len(m.input[m.mode]) {
+				newInput += m.input[m.mode][m.cursorPos:]
+			}
+
+			m.input[m.mode] = newInput
+			m.updateCursor(m.cursorPos - 1)
+			m.updateInput()
+		}
+		return nil
+	},
+}
+
+// navigateActions are the motions of navigate mode, which move the cursor
+// n digits, or to either end, instead of the usual actions of their keys.
+var navigateActions = map[string]func(m *model, n int){
+	"i":     func(m *model, n int) { m.navigate = false },
+	"left":  cursorBack,
+	"h":     cursorBack,
+	"right": cursorForward,
+	"l":     cursorForward,
+	"0":     func(m *model, n int) { m.updateCursor(0) },
+	"$":     func(m *model, n int) { m.updateCursor(len(m.input[m.mode])) },
+}
+
+// boundKey reports whether key is bound to an action in either mode, so
+// that extra rows can't take it over.
+func boundKey(key string) bool {
+	_, ok := keyActions[key]
+	_, motion := navigateActions[key]
+	return ok || motion
+}
+
+// quit quits the program.
+func quit(m *model, key string) tea.Cmd {
+	return tea.Quit
+}
+
+// cursorLeft moves the cursor a digit to the left.
+func cursorLeft(m *model, key string) tea.Cmd {
+	if m.cursorPos > 0 {
+		m.updateCursor(m.cursorPos - 1)
+	}
+	return nil
+}
+
+// cursorRight moves the cursor a digit to the right.
+func cursorRight(m *model, key string) tea.Cmd {
+	if m.cursorPos < len(m.input[m.mode]) {
+		m.updateCursor(m.cursorPos + 1)
+	}
+	return nil
+}
+
+// focusUp moves the cursor up a row of the binary grid, or focuses the
+// base above.
+func focusUp(m *model, key string) tea.Cmd {
+	if m.grid && m.mode == Binary && m.cursorPos >= gridColumns {
+		m.updateCursor(m.cursorPos - gridColumns)
+	} else {
+		m.mode = clamp(m.mode-1, Binary, Hexadecimal)
+		m.updateCursor(m.cursorPos)
+	}
+	return nil
+}
+
+// focusDown moves the cursor down a row of the binary grid, or focuses
+// the base below.
+func focusDown(m *model, key string) tea.Cmd {
+	if m.grid && m.mode == Binary && m.cursorPos+gridColumns <= len(m.input[m.mode]) {
+		m.updateCursor(m.cursorPos + gridColumns)
+	} else {
+		m.mode = clamp(m.mode+1, Binary, Hexadecimal)
+		m.updateCursor(m.cursorPos)
+	}
+	return nil
+}
+
+// setOneHot sets the value to a single set bit, or with O a single clear
+// bit, at the cursor.
+func setOneHot(m *model, key string) tea.Cmd {
+	if v, err := oneHot(m.value(), m.width, key == "O"); err != nil {
+		m.err = err
+	} else {
+		m.setValue(v)
+	}
+	return nil
+}
+
+// recallKey recalls the named value stored in the slot of the digit of
+// alt+1 to alt+9.
+func recallKey(m *model, key string) tea.Cmd {
+	m.recallNamed(int(key[len(key)-1] - '1'))
+	return nil
+}
+
+// cursorBack moves the cursor n digits to the left in navigate mode.
+func cursorBack(m *model, n int) {
+	m.updateCursor(m.cursorPos - n)
+}
+
+// cursorForward moves the cursor n digits to the right in navigate mode.
+func cursorForward(m *model, n int) {
+	m.updateCursor(m.cursorPos + n)
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type kioskTickMsg time.Time
+
+func kioskTick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return kioskTickMsg(t)
+	})
+}
+
+// advanceKiosk focuses the next base if the rotation isn't paused and no
+// key was pressed for a whole kiosk interval.
+func (m *model) advanceKiosk(now time.Time) tea.Cmd {
+	if !m.kioskPaused && now.Sub(m.lastInput) >= m.kiosk {
+		m.mode = (m.mode + 1) % (Hexadecimal + 1)
+		m.updateCursor(m.cursorPos)
+	}
+	return kioskTick(m.kiosk)
+}
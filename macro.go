@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// macro is a named sequence of steps from the config, applied to the value
+// one after another, like "shl 4", "or 0x0F" and "hex".
+type macro struct {
+	Name  string   `json:"name"`
+	Steps []string `json:"steps"`
+	steps []macroStep
+}
+
+// macroStep is a parsed step of a macro: an operation and its operand, or
+// a base to focus.
+type macroStep struct {
+	op      string
+	operand uint64
+	focus   bool
+	radix   radix
+}
+
+// macroOps are the operations a macro step can apply, wrapping around on
+// overflow. not and neg take no operand and work at the current width.
+var macroOps = map[string]func(v, n uint64, width int) uint64{
+	"shl": func(v, n uint64, width int) uint64 { return v << n },
+	"shr": func(v, n uint64, width int) uint64 { return v >> n },
+	"and": func(v, n uint64, width int) uint64 { return v & n },
+	"or":  func(v, n uint64, width int) uint64 { return v | n },
+	"xor": func(v, n uint64, width int) uint64 { return v ^ n },
+	"add": func(v, n uint64, width int) uint64 { return v + n },
+	"sub": func(v, n uint64, width int) uint64 { return v - n },
+	"mul": func(v, n uint64, width int) uint64 { return v * n },
+	"div": func(v, n uint64, width int) uint64 { return v / n },
+	"not": func(v, n uint64, width int) uint64 { return ^v & mask(width) },
+	"neg": func(v, n uint64, width int) uint64 { return twosComplement(v, width) },
+}
+
+// parseMacroStep parses a step like "shl 4" or "or 0x0F", or a base name
+// like "hex" to focus that base.
+func parseMacroStep(s string) (macroStep, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return macroStep{}, errors.New("empty step")
+	}
+
+	op := strings.ToLower(fields[0])
+	if _, ok := macroOps[op]; !ok {
+		r, err := parseRadix(op)
+		if err != nil || len(fields) > 1 {
+			return macroStep{}, fmt.Errorf("unknown step %q", s)
+		}
+		return macroStep{focus: true, radix: r}, nil
+	}
+
+	if op == "not" || op == "neg" {
+		if len(fields) > 1 {
+			return macroStep{}, fmt.Errorf("step %q takes no operand", s)
+		}
+		return macroStep{op: op}, nil
+	}
+	if len(fields) != 2 {
+		return macroStep{}, fmt.Errorf("step %q takes one operand", s)
+	}
+
+	n, _, err := parseLiteral(fields[1], Decimal)
+	if err != nil {
+		return macroStep{}, fmt.Errorf("step %q: invalid operand %q", s, fields[1])
+	}
+	if op == "div" && n == 0 {
+		return macroStep{}, fmt.Errorf("step %q divides by zero", s)
+	}
+	return macroStep{op: op, operand: n}, nil
+}
+
+// runMacro applies the steps of the macro called name to the value as a
+// single undoable edit.
+func (m *model) runMacro(name string) error {
+	name = strings.TrimSpace(name)
+	for _, mc := range m.macros {
+		if mc.Name != name {
+			continue
+		}
+
+		v, mode := m.value(), m.mode
+		for _, step := range mc.steps {
+			if step.focus {
+				mode = step.radix
+			} else {
+				v = macroOps[step.op](v, step.operand, m.width)
+			}
+		}
+		m.setValue(v)
+		m.mode = mode
+		m.updateCursor(m.cursorPos)
+		m.status = fmt.Sprintf("ran %s: %s", mc.Name, strings.Join(mc.Steps, ", "))
+		return nil
+	}
+	return fmt.Errorf("no macro called %q", name)
+}
+
+// macroNames lists the macros for the macro prompt.
+func (m model) macroNames() string {
+	names := make([]string, len(m.macros))
+	for i, mc := range m.macros {
+		names[i] = mc.Name
+	}
+	return strings.Join(names, ", ")
+}
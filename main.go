@@ -1,10 +1,15 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io/fs"
+	"math/bits"
 	"os"
-	"strconv"
+	"slices"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/charmbracelet/bubbles/cursor"
@@ -20,6 +25,9 @@ const (
 	Hexadecimal
 )
 
+// gridColumns is the number of bits per line in grid mode.
+const gridColumns = 8
+
 type errMsg struct {
 	msg string
 }
@@ -33,24 +41,210 @@ type model struct {
 	mode      radix
 	cursor    cursor.Model
 	cursorPos int
+	fields    []fieldSpec
+	flags     []flagSpec
+	constants []constant
+	macros    []macro
+	mixed     []uint64
+	version   []fieldSpec
+	history   history
+	shownRows []bool
+	grouping  bool
+	separator string
+	keepZeros bool
+
+	// quietOverflow ignores digits that would overflow the value instead
+	// of reporting an error.
+	quietOverflow bool
+
+	showValid bool
+	grid      bool
+	showTrail bool
+	bigDigits bool
+	msbLabels bool
+	nibbleHex bool
+	trail     []trailPoint
+	prompt    *prompt
+	slots     []slot
+	slot      int
+	showDiff  bool
+	preview   *clipboardPreview
+	division  *division
+	mask      uint64
+	alphabet  []rune
+	step      uint64
+
+	// base is the base the value is also shown in, or 0 for none.
+	// recentBases are the bases shown most recently, most recent first.
+	base        int
+	recentBases []int
+
+	// shadeNibbles shades the low and high nibble of each byte of the
+	// binary row, explained by a legend if showLegend is set.
+	shadeNibbles bool
+	showLegend   bool
+
+	// angleUnit is the unit the value is read as an angle in, as an index
+	// into angleUnits plus one, or 0 to hide the angle.
+	angleUnit int
+
+	// floatOrder is the byte order the value is decoded as a float in, as
+	// an index into floatOrders plus one, or 0 to hide the float.
+	floatOrder int
+
+	// kiosk is how long to wait without input before focusing the next
+	// base, or 0 to never do so.
+	kiosk     time.Duration
+	lastInput time.Time
+
+	// kioskPaused stops the kiosk rotation until space is pressed again.
+	kioskPaused bool
+
+	// autosaveEvery is how often to save the state while running, or 0 to
+	// only save it on exit.
+	autosaveEvery time.Duration
+
+	// idle is how long to wait without input before quitting, or 0 to keep
+	// running.
+	idle time.Duration
+
+	// setTitle keeps the terminal title showing the value.
+	setTitle bool
+
+	// asmSyntax is the assembler syntax the value is copied as an immediate
+	// in, one of asmSyntaxes.
+	asmSyntax string
+
+	// theme is the name of the theme in use, saved with the settings.
+	theme string
+
+	// height is the terminal height, or 0 until it is known, and scroll
+	// the first line of the view shown when it doesn't fit.
+	height int
+	scroll int
+
+	// columns is the terminal width, or 0 until it is known. Rows wider
+	// than it show the digits around the cursor, before it unless
+	// anchorAfter is set.
+	columns     int
+	anchorAfter bool
+
+	// box frames the view in a border, with the title above the content
+	// and the status bar below it.
+	box bool
+
+	// width is the number of bits signed interpretations look at.
+	// negative is a sign typed for the value, which governs the signed
+	// decimal rather than the high bit if typedSign is set.
+	width      int
+	dualSigned bool
+	negative   bool
+	typedSign  bool
+	showWidths bool
+	checksums  bool
+
+	showNeighbours bool
+	rightAlign     bool
+	relativeBits   bool
+
+	// emphasizeCompact emphasizes the row of the base representing the
+	// value in the fewest digits.
+	emphasizeCompact bool
+
+	// summing makes enter add the value to a running total, which has
+	// summed values added so far.
+	summing bool
+	total   uint64
+	summed  int
+
+	// navigate makes digits counts for motions rather than input, until
+	// edit mode is entered again. count is the pending count.
+	navigate bool
+	count    int
+
+	// bitPattern makes rows that support it read the value as the bits of
+	// the current width rather than as a number.
+	bitPattern bool
+
+	// raw is the focused row as last typed, before normalization.
+	raw     string
+	rawMode radix
+	showRaw bool
+	status  string
+	err     error
+
+	register    uint64
+	hasRegister bool
+	named       []namedSlot
+
+	// clipValue, if hasClipValue is set, is the value read from the
+	// clipboard to compare with.
+	clipValue    uint64
+	hasClipValue bool
 }
 
-func initialModel() model {
+func initialModel(cfg config) model {
 	c := cursor.New()
 	c.SetChar("0")
 	cursor.Blink()
 	c.Focus()
 
-	return model{
+	m := model{
 		input:     [4]string{"", "", "", ""},
 		mode:      Decimal,
 		cursor:    c,
 		cursorPos: 0,
+		fields:    cfg.Fields,
+		flags:     cfg.Flags,
+		constants: cfg.Constants,
+		macros:    cfg.Macros,
+		mixed:     cfg.MixedRadix,
+		version:   cfg.version,
+		shownRows: make([]bool, len(extraRows)),
+		slots:     make([]slot, 1),
+		width:     64,
+		kiosk:     time.Duration(cfg.Kiosk) * time.Second,
+		idle:      time.Duration(cfg.Idle) * time.Second,
+		lastInput: time.Now(),
+		separator: cfg.Separator,
+		setTitle:  !cfg.NoTitle,
+		asmSyntax: cfg.AsmSyntax,
+	}
+	for i := len(cfg.Recent) - 1; i >= 0; i-- {
+		m.useBase(cfg.Recent[i])
+	}
+	if cfg.Persist {
+		m.autosaveEvery = time.Duration(cfg.Autosave) * time.Second
 	}
+	m.applySettings(cfg.Settings)
+	if err := applyTheme(cfg.Theme); err != nil {
+		m.status = "warning: " + err.Error()
+	} else {
+		m.theme = cfg.Theme
+	}
+	if err := checkAsmSyntax(cfg.AsmSyntax); err != nil {
+		m.status = "warning: " + err.Error()
+	}
+	return m
+}
+
+// value returns the number currently entered.
+func (m model) value() uint64 {
+	return parseInt(m.input[Decimal], Decimal)
 }
 
 func (m model) Init() tea.Cmd {
-	return cursor.Blink
+	cmds := []tea.Cmd{cursor.Blink, m.updateTitle("")}
+	if m.kiosk > 0 {
+		cmds = append(cmds, kioskTick(m.kiosk))
+	}
+	if m.autosaveEvery > 0 {
+		cmds = append(cmds, autosaveTick(m.autosaveEvery))
+	}
+	if m.idle > 0 {
+		cmds = append(cmds, idleTick(m.idle))
+	}
+	return tea.Batch(cmds...)
 }
 
 func clamp[T int | radix](v, low, high T) T {
@@ -60,14 +254,14 @@ func clamp[T int | radix](v, low, high T) T {
 	return min(high, max(low, v))
 }
 
-func min[T int | radix](a, b T) T {
+func min[T int | uint64 | radix](a, b T) T {
 	if a < b {
 		return a
 	}
 	return b
 }
 
-func max[T int | radix](a, b T) T {
+func max[T int | uint64 | radix](a, b T) T {
 	if a > b {
 		return a
 	}
@@ -88,37 +282,68 @@ func (m *model) updateCursor(newPos int) {
 }
 
 func (m *model) updateInput() {
-	var i uint64
-	switch m.mode {
-	case Binary:
-		i = parseInt(m.input[m.mode], 2)
-	case Octal:
-		i = parseInt(m.input[m.mode], 8)
-	case Decimal:
-		i = parseInt(m.input[m.mode], 10)
-	case Hexadecimal:
-		i = parseInt(m.input[m.mode], 16)
+	raw := m.input[m.mode]
+	m.raw, m.rawMode = raw, m.mode
+	m.input = formatAll(parseInt(raw, m.mode))
+	if m.keepZeros {
+		m.input[m.mode] = raw
 	}
+}
 
-	if i == 0 {
-		for mode := Binary; mode <= Hexadecimal; mode++ {
-			m.input[mode] = ""
+// resync regenerates every row from the decimal one, recovering from rows
+// getting out of sync.
+func (m *model) resync() {
+	mode := m.mode
+	m.mode = Decimal
+	m.updateInput()
+	m.mode = mode
+	m.updateCursor(m.cursorPos)
+}
+
+// setValue replaces the current value with v as an undoable edit.
+func (m *model) setValue(v uint64) {
+	m.record()
+	m.input = formatAll(v)
+	m.raw = ""
+	m.updateCursor(m.cursorPos)
+}
+
+// insertDigit inserts the digit d at the cursor. A digit that would make
+// the value overflow 64 bits is rejected, with an error unless
+// quietOverflow is set.
+func (m *model) insertDigit(d string) {
+	input := m.input[m.mode][:m.cursorPos] + d + m.input[m.mode][m.cursorPos:]
+	if _, err := parse(input, m.mode); err != nil {
+		if !m.quietOverflow {
+			m.err = errMsg{"value exceeds 64 bits"}
 		}
 		return
-	} else {
-		for mode := Binary; mode <= Hexadecimal; mode++ {
-			switch mode {
-			case Binary:
-				m.input[mode] = fmt.Sprintf("%b", i)
-			case Octal:
-				m.input[mode] = fmt.Sprintf("%o", i)
-			case Decimal:
-				m.input[mode] = fmt.Sprintf("%d", i)
-			case Hexadecimal:
-				m.input[mode] = strings.ToUpper(fmt.Sprintf("%x", i))
-			}
-		}
 	}
+
+	m.record()
+	m.input[m.mode] = input
+	m.updateInput()
+	m.updateCursor(m.cursorPos + 1)
+}
+
+// maxDigit returns the highest digit of radix r, like "F" for hexadecimal.
+func maxDigit(r radix) string {
+	return format(uint64(r.base()-1), r)
+}
+
+// zeroDigit sets the digit under the cursor to zero without shifting the
+// others. The cursor stays on the same digit unless it was a leading one
+// that normalization dropped.
+func (m *model) zeroDigit() {
+	s := m.input[m.mode]
+	if m.cursorPos >= len(s) {
+		return
+	}
+
+	m.record()
+	m.input[m.mode] = s[:m.cursorPos] + "0" + s[m.cursorPos+1:]
+	m.updateInput()
+	m.updateCursor(m.cursorPos - (len(s) - len(m.input[m.mode])))
 }
 
 func isValidDigit(c rune, r radix) bool {
@@ -136,74 +361,169 @@ func isValidDigit(c rune, r radix) bool {
 	return false
 }
 
+// validDigits describes the digits accepted by isValidDigit for radix r as
+// ranges, like "0-9A-F".
+func validDigits(r radix) string {
+	b := strings.Builder{}
+
+	for _, set := range [][2]rune{{'0', '9'}, {'a', 'z'}} {
+		start := rune(-1)
+		for c := set[0]; c <= set[1]+1; c++ {
+			if c <= set[1] && isValidDigit(c, r) {
+				if start < 0 {
+					start = c
+				}
+				continue
+			}
+
+			if start >= 0 {
+				b.WriteRune(unicode.ToUpper(start))
+				if c-1 > start {
+					b.WriteRune('-')
+					b.WriteRune(unicode.ToUpper(c - 1))
+				}
+				start = -1
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// nextSetBit returns the position of the first set bit to the right of pos
+// in the binary representation s, or pos if there is none.
+func nextSetBit(s string, pos int) int {
+	for i := pos + 1; i < len(s); i++ {
+		if s[i] == '1' {
+			return i
+		}
+	}
+	return pos
+}
+
+// prevSetBit returns the position of the first set bit to the left of pos
+// in the binary representation s, or pos if there is none.
+func prevSetBit(s string, pos int) int {
+	for i := min(pos, len(s)) - 1; i >= 0; i-- {
+		if s[i] == '1' {
+			return i
+		}
+	}
+	return pos
+}
+
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	oldPos := m.cursorPos
 	oldMode := m.mode
+	oldTitle := m.title()
+
+	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
+	case errMsg:
+		m.err = msg
+	case copiedMsg:
+		m.status = fmt.Sprintf("copied %s", string(msg))
+	case clipboardMsg:
+		m.preview = m.previewClipboard(string(msg))
+	case diffTargetMsg:
+		if v, _, err := parseLiteral(m.ungroup(string(msg)), m.mode); err != nil {
+			m.err = fmt.Errorf("clipboard: %w", err)
+		} else {
+			m.clipValue = v
+			m.hasClipValue = true
+		}
+	case alphabetMsg:
+		if alphabet, err := parseAlphabet(string(msg)); err != nil {
+			m.err = err
+		} else {
+			m.alphabet = alphabet
+		}
+	case trailTickMsg:
+		cmds = append(cmds, m.decayTrail(time.Time(msg)))
+	case kioskTickMsg:
+		cmds = append(cmds, m.advanceKiosk(time.Time(msg)))
+	case autosaveTickMsg:
+		cmds = append(cmds, m.autosave())
+	case idleTickMsg:
+		cmds = append(cmds, m.checkIdle(time.Time(msg)))
+	case tea.WindowSizeMsg:
+		m.height = msg.Height
+		m.columns = msg.Width
+		m.scrollBy(0)
 	case tea.KeyMsg:
+		m.err = nil
+		m.status = ""
+		m.lastInput = time.Now()
+
+		if m.preview != nil {
+			if msg.Type == tea.KeyEnter && m.preview.err == nil {
+				m.setValue(m.preview.value)
+			}
+			m.preview = nil
+			break
+		}
+
+		if m.prompt != nil {
+			m.updatePrompt(msg)
+			break
+		}
+
+		if m.division != nil {
+			if m.division.Update(msg) {
+				m.division = nil
+			}
+			break
+		}
+
+		if msg.Paste {
+			if v, _, err := parseLiteral(m.ungroup(string(msg.Runes)), m.mode); err != nil {
+				m.err = err
+			} else {
+				m.setValue(v)
+				m.updateCursor(len(m.input[m.mode]))
+			}
+			break
+		}
+
 		key := msg.String()
-		if len(key) == 1 && isValidDigit(unicode.ToLower(rune(key[0])), m.mode) {
-			if key[0] == '0' && m.cursorPos == 0 {
+		if m.navigate && m.updateNavigate(key) {
+			break
+		}
+
+		if !m.navigate && len(key) == 1 && isValidDigit(unicode.ToLower(rune(key[0])), m.mode) {
+			if key[0] == '0' && m.cursorPos == 0 && !m.keepZeros {
 				break
 			}
-			m.input[m.mode] = m.input[m.mode][:m.cursorPos] + key + m.input[m.mode][m.cursorPos:]
-			m.updateInput()
-			m.updateCursor(m.cursorPos + 1)
-		} else {
-			switch key {
-			case "ctrl+c", "q":
-				return m, tea.Quit
-			case "left", "h":
-				if m.cursorPos > 0 {
-					m.updateCursor(m.cursorPos - 1)
-				}
-			case "right", "l":
-				if m.cursorPos < len(m.input[m.mode]) {
-					m.updateCursor(m.cursorPos + 1)
-				}
-			case "up", "k":
-				m.mode = clamp(m.mode-1, Binary, Hexadecimal)
-				m.updateCursor(m.cursorPos)
-			case "down", "j":
-				m.mode = clamp(m.mode+1, Binary, Hexadecimal)
-				m.updateCursor(m.cursorPos)
-			case "backspace":
-				if m.cursorPos > 0 {
-					newPos := m.cursorPos - 1
-					newInput := m.input[m.mode][:newPos]
-					if m.cursorPos < len(m.input[m.mode]) {
-						newInput += m.input[m.mode][m.cursorPos:]
-					}
-
-					m.input[m.mode] = newInput
-					m.updateCursor(m.cursorPos - 1)
-					m.updateInput()
-				}
-			}
+			m.insertDigit(key)
+		} else if i := extraRowIndex(key); i >= 0 {
+			m.showRow(i, !m.rowShown(i))
+		} else if action, ok := keyActions[key]; ok {
+			cmds = append(cmds, action(&m, key))
 		}
 	}
 
-	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
 	m.cursor, cmd = m.cursor.Update(msg)
 	cmds = append(cmds, cmd)
 
+	if m.showTrail && (oldMode != m.mode || oldPos != m.cursorPos) {
+		cmds = append(cmds, m.leaveTrail(oldMode, oldPos))
+	}
+
 	if (oldMode != m.mode || oldPos != m.cursorPos) && m.cursor.Mode() == cursor.CursorBlink {
 		m.cursor.Blink = false
 		cmds = append(cmds, m.cursor.BlinkCmd())
 	}
 
+	cmds = append(cmds, m.updateTitle(oldTitle))
+
 	return m, tea.Batch(cmds...)
 }
 
-func parseInt(s string, base int) uint64 {
-	if len(s) == 0 {
-		return 0
-	}
-
-	i, err := strconv.ParseUint(s, base, 64)
+func parseInt(s string, r radix) uint64 {
+	i, err := parse(s, r)
 
 	if err != nil {
 		fmt.Printf("Error: %s\n", err.Error())
@@ -228,37 +548,489 @@ func formatMode(mode radix) string {
 }
 
 func (m model) View() string {
+	if m.box {
+		return m.withSidebar(m.boxed(m.page(m.content())))
+	}
+	return m.withSidebar(m.page(m.content()))
+}
+
+// content renders the whole view, however tall.
+func (m model) content() string {
+	b := strings.Builder{}
+
+	if len(m.slots) > 1 {
+		b.WriteString(m.slotsView())
+	} else {
+		b.WriteString(m.rowsView())
+	}
+
+	if m.shadeNibbles && m.showLegend {
+		b.WriteString(nibbleLegend())
+	}
+
+	if m.showDiff {
+		b.WriteString("\n" + m.diffView())
+	}
+
+	if m.hasClipValue {
+		b.WriteString(m.clipboardDiffView())
+	}
+
+	for i, row := range extraRows {
+		if m.rowShown(i) {
+			b.WriteString(fmt.Sprintf("%s: %s\n", row.row.Label(), m.viewRow(row.row)))
+		}
+	}
+
+	if m.showWidths {
+		b.WriteString(m.widthsView())
+	}
+
+	if m.checksums {
+		b.WriteString(m.checksumsView())
+	}
+
+	if m.showNeighbours {
+		b.WriteString(m.neighboursView())
+	}
+
+	if m.summing {
+		b.WriteString(m.totalView())
+	}
+
+	if len(m.fields) > 0 {
+		b.WriteString(m.fieldsView())
+	}
+
+	if len(m.flags) > 0 {
+		b.WriteString(fmt.Sprintf("\nflags: %s\n", decodeFlags(m.flags, m.value())))
+	}
+
+	if len(m.constants) > 0 {
+		b.WriteString(fmt.Sprintf("\nconstant: %s\n", nearestConstant(m.constants, m.value())))
+	}
+
+	if m.angleUnit > 0 {
+		b.WriteString(angleView(m.angleUnit, m.value()))
+	}
+
+	if m.floatOrder > 0 {
+		b.WriteString(m.floatView(m.floatOrder))
+	}
+
+	if m.base > 0 {
+		b.WriteString(m.baseView())
+	}
+
+	if m.alphabet != nil {
+		b.WriteString(fmt.Sprintf("\nbase %d: %s\n", len(m.alphabet), encodeAlphabet(m.value(), m.alphabet)))
+	}
+
+	if len(m.version) > 0 {
+		b.WriteString(fmt.Sprintf("\nversion: %s\n", decodeVersion(m.version, m.value())))
+	}
+
+	if len(m.mixed) > 0 {
+		b.WriteString(fmt.Sprintf("\nmixed: %s\n", decodeMixedRadix(m.mixed, m.value())))
+	}
+
+	if m.prompt != nil {
+		b.WriteString(fmt.Sprintf("\n%s\n", m.prompt.View()))
+	}
+
+	if m.preview != nil {
+		b.WriteString("\n" + m.preview.View())
+	}
+
+	if m.division != nil {
+		b.WriteString("\n" + m.division.View())
+	}
+
+	if bar := m.statusBar(); len(bar) > 0 && !m.box {
+		b.WriteString(fmt.Sprintf("\n%s\n", bar))
+	}
+
+	if m.err != nil {
+		b.WriteString(fmt.Sprintf("\nError: %v\n", m.err))
+	} else if len(m.status) > 0 {
+		b.WriteString(fmt.Sprintf("\n%s\n", m.status))
+	}
+
+	if m.showValid {
+		b.WriteString(fmt.Sprintf("\nvalid: %s\n", validDigits(m.mode)))
+	}
+
+	return b.String()
+}
+
+// rowsView renders the conversions of the value being edited.
+func (m model) rowsView() string {
 	b := strings.Builder{}
 
+	var digits [4]string
 	for r := Binary; r <= Hexadecimal; r++ {
-		if r != m.mode {
-			var view string
-			if len(m.input[r]) == 0 {
-				view = "0"
-			} else {
-				view = m.input[r]
+		if m.bigDigits && r == m.mode {
+			continue
+		}
+		digits[r] = m.digitsView(r)
+		if m.msbLabels && r == Binary && !m.grid {
+			digits[r] = "MSB " + digits[r] + " LSB"
+		}
+	}
+	var pad [4]int
+	if m.rightAlign {
+		pad = alignRight(m.digitWidths(digits))
+	}
+
+	for r := Binary; r <= Hexadecimal; r++ {
+		if m.bigDigits && r == m.mode {
+			indent := strings.Repeat(" ", len(formatMode(r))+2)
+			for i, line := range bigDigits(m.input[r]) {
+				if i == 0 {
+					b.WriteString(fmt.Sprintf("%s: %s\n", formatMode(r), line))
+				} else {
+					b.WriteString(indent + line + "\n")
+				}
 			}
+			continue
+		}
 
-			b.WriteString(fmt.Sprintf("%s: %s\n", formatMode(r), view))
-		} else {
-			view := m.input[r][:m.cursorPos] + m.cursor.View()
+		b.WriteString(fmt.Sprintf("%s: %s%s%s%s\n", m.rowLabel(r), strings.Repeat(" ", pad[r]), digits[r], m.signedNote(r), m.rawNote(r)))
+		if m.nibbleHex && r == Binary {
+			b.WriteString(m.nibbleHexLine(pad[r]))
+		}
+	}
+
+	return b.String()
+}
 
-			if m.cursorPos < len(m.input[r]) {
-				view += m.input[r][m.cursorPos+1:]
+// digitsView renders the digits of radix r, drawing the cursor if r is
+// focused and separating digit groups if grouping is enabled.
+func (m model) digitsView(r radix) string {
+	s := m.input[r]
+	focused := r == m.mode
+	if len(s) == 0 && !focused {
+		return "0"
+	}
+
+	grid := r == Binary && m.grid
+	b := strings.Builder{}
+	if grid {
+		b.WriteString(strings.Repeat("  ", (gridColumns-len(s)%gridColumns)%gridColumns))
+	}
+
+	start, end := 0, len(s)
+	if !grid {
+		c := len(s)
+		if focused {
+			c = m.cursorPos
+		}
+		start, end = m.digitWindow(len(s), c, groupSize(r), m.groupSeparator(r))
+	}
+	if start > 0 {
+		b.WriteString("…")
+	}
+
+	for i := start; i < end; i++ {
+		if grid {
+			if startsGroup(i, len(s), gridColumns) {
+				b.WriteString("\n" + strings.Repeat(" ", len("bin: ")))
+			} else if i > 0 {
+				b.WriteString(" ")
 			}
+		} else if m.grouping && i > start && startsGroup(i, len(s), groupSize(r)) {
+			b.WriteString(m.groupSeparator(r))
+		}
+		if focused && i == m.cursorPos {
+			b.WriteString(m.cursor.View())
+		} else if m.inTrail(r, i) {
+			b.WriteString(trailStyle.Render(s[i : i+1]))
+		} else if m.masked(r, i, len(s)) {
+			b.WriteString(maskStyle.Render(s[i : i+1]))
+		} else if m.shadeNibbles && r == Binary {
+			b.WriteString(nibbleStyle(i, len(s)).Render(s[i : i+1]))
+		} else {
+			b.WriteByte(s[i])
+		}
+	}
+	if end < len(s) {
+		b.WriteString("…")
+	}
+	if focused && m.cursorPos == len(s) {
+		if grid && len(s) > 0 {
+			b.WriteString(" ")
+		}
+		b.WriteString(m.cursor.View())
+	}
+
+	return b.String()
+}
+
+// signedNote returns the signed interpretation of the value for the
+// decimal row if it differs from the unsigned one: negative if the sign is
+// typed and governs, or if dual display is on and the high bit is set.
+func (m model) signedNote(r radix) string {
+	v := m.value()
+	switch {
+	case r != Decimal:
+		return ""
+	case m.typedSign:
+		if !m.negative || v == 0 {
+			return ""
+		}
+		return fmt.Sprintf("  (signed -%d)", v)
+	case !m.dualSigned || !signBit(v, m.width):
+		return ""
+	}
+	return fmt.Sprintf("  (signed %d)", signedValue(v, m.width))
+}
+
+// rawNote returns a note with the digits typed into radix r if
+// normalization changed them.
+func (m model) rawNote(r radix) string {
+	if !m.showRaw || r != m.rawMode || len(m.raw) == 0 {
+		return ""
+	}
+
+	normalized := formatValue(m.value(), r)
+	if m.raw == normalized {
+		return ""
+	}
+	return fmt.Sprintf("  (typed %s → %s)", m.raw, normalized)
+}
 
-			b.WriteString(fmt.Sprintf("%s: %s\n", formatMode(r), view))
+// renderRow renders an extra row, reading the value as a bit pattern if
+// that is toggled on and the row supports it.
+func (m model) renderRow(row Row) string {
+	if pr, ok := row.(patternRow); ok && m.bitPattern {
+		return pr.RenderPattern(m.value()&mask(m.width), m.width)
+	}
+	return row.Render(m.value(), m.width)
+}
 
+// viewRow renders an extra row for the view, styling it as plain rendering
+// can't, like with the swatch of a color row.
+func (m model) viewRow(row Row) string {
+	text := m.renderRow(row)
+	if sr, ok := row.(swatchRow); ok {
+		if hex, ok := sr.Swatch(m.value()); ok {
+			text = swatchBlock(hex) + " " + text
 		}
 	}
+	return text
+}
+
+// statusBar returns the persistent status line.
+func (m model) statusBar() string {
+	var parts []string
+
+	if mode := m.modeLine(); len(mode) > 0 {
+		parts = append(parts, mode)
+	}
+
+	if m.width != 64 || m.dualSigned {
+		parts = append(parts, fmt.Sprintf("%d-bit", m.width))
+	}
+
+	if m.typedSign {
+		sign := "+"
+		if m.negative {
+			sign = "-"
+		}
+		parts = append(parts, "sign: typed "+sign)
+	} else if m.dualSigned {
+		parts = append(parts, "sign: high bit")
+	}
+
+	if m.bitPattern {
+		parts = append(parts, "bit pattern")
+	}
+
+	if m.step != 0 {
+		parts = append(parts, fmt.Sprintf("step: %d", m.step))
+	}
+
+	if m.relativeBits {
+		parts = append(parts, "bits: relative")
+	}
+
+	if m.kioskPaused {
+		parts = append(parts, "kiosk: paused")
+	}
+
+	if m.hasRegister {
+		parts = append(parts, fmt.Sprintf("reg: 0x%X", m.register))
+		parts = append(parts, fmt.Sprintf("Δbits: %d", bits.OnesCount64(m.value()^m.register)))
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+func (m model) fieldsView() string {
+	b := strings.Builder{}
+	v := m.value()
+
+	width := 0
+	for _, f := range m.fields {
+		width = max(width, len(f.Name))
+	}
+
+	b.WriteString("\n")
+	for _, f := range m.fields {
+		b.WriteString(fmt.Sprintf("%-*s [%d:%d]: %d\n", width, f.Name, f.high, f.low, f.extract(v)))
+	}
 
 	return b.String()
 }
 
 func main() {
-	p := tea.NewProgram(initialModel())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error occured: %v", err)
+	from := flag.String("from", "", "base of command-line values; detected from their prefix or suffix if unset")
+	to := flag.String("to", "dec", "comma-separated bases to convert command-line values to (bin, oct, dec, hex)")
+	file := flag.String("file", "", "convert each line of the file (- for standard input)")
+	width := flag.Int("width", 64, "number of bits command-line values must fit in, and the width the interactive converter opens at")
+	pad := flag.Int("pad", 0, "left-pad converted command-line values with zeros to this many digits")
+	frac := flag.Int("frac", 16, "most fractional digits to print for command-line values with a radix point, like 0.1")
+	round := flag.String("round", "half-even", "rounding of fractional digits cut off by -frac (truncate, half-even, half-up)")
+	unit := flag.String("unit", "", "append this unit to converted command-line values, like bytes")
+	signed := flag.Bool("signed", false, "read command-line values as two's complement, accepting negative input and printing signed decimals")
+	view := flag.String("view", "", "print command-line values as the extra row of this label, like ipv4, instead of converting them")
+	via := flag.String("via", "", "convert command-line values to this base and back before output, failing if they change")
+	format := flag.String("format", "", "print command-line values with a Go template using .Bin, .Oct, .Dec, .Hex and .Value")
+	rng := flag.String("range", "", "convert every value of an inclusive range, like 0-15")
+	send := flag.String("send", "", "write the final value of the interactive converter to this Unix socket or named pipe")
+	theme := flag.String("theme", "", "theme of the interactive converter (default, mono)")
+	gentable := flag.Bool("gentable", false, "write the conversions of -range to -out as a table, or as a C array of strings with -format carray")
+	out := flag.String("out", "", "file -gentable writes to, standard output if unset")
+	addr := flag.String("serve", "", "serve conversions over HTTP on this address, like :8080, at /convert?v=FF&from=hex&to=dec")
+	interactive := flag.Bool("i", false, "open the interactive converter with the command-line values as comparison slots")
+	flag.Parse()
+
+	// With -gentable, -format carray picks the table format rather than
+	// being a template.
+	carray := *gentable && *format == "carray"
+	if carray {
+		*format = ""
+	}
+
+	opts, err := newCLIOptions(*from, *to, *view, *via, *format, *unit, *width, *pad, *signed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if *frac < 0 {
+		fmt.Fprintf(os.Stderr, "Error: frac %d is negative\n", *frac)
+		os.Exit(1)
+	}
+	opts.fracDigits = *frac
+	if opts.rounding, err = parseRounding(*round); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *gentable {
+		if err := genTable(*rng, *out, carray, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(*addr) > 0 {
+		fmt.Fprintf(os.Stderr, "serving conversions on %s\n", *addr)
+		if err := serve(*addr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(*rng) > 0 {
+		if err := runRange(*rng, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !*interactive && (flag.NArg() > 0 || len(*file) > 0 || isPipe(os.Stdin)) {
+		if err := runCLI(flag.Args(), *file, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	if len(*theme) > 0 {
+		cfg.Theme = *theme
+	}
+	if len(*send) > 0 {
+		cfg.Send = *send
+	}
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "width" {
+			cfg.Settings.Width = *width
+		}
+	})
+
+	m := initialModel(cfg)
+	if i := extraRowByLabel(*view); i >= 0 {
+		m.showRow(i, true)
+	}
+	// Named slots are kept whether or not persist is set; the rest of the
+	// state only with it.
+	saved, err := loadState()
+	keepNamed := err == nil || errors.Is(err, fs.ErrNotExist)
+	if err == nil {
+		if cfg.Persist {
+			m.restoreState(saved)
+		} else {
+			m.named = saved.Named
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("Error loading state: %v\n", err)
+	}
+
+	if flag.NArg() > 0 {
+		values := make([]uint64, flag.NArg())
+		for i, arg := range flag.Args() {
+			if values[i], err = opts.parse(arg); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		m.setSlots(values)
+	}
+
+	p := tea.NewProgram(m)
+	final, runErr := p.Run()
+
+	// Save before reporting a failed run, so a killed session still keeps
+	// the last value it processed.
+	if f, ok := final.(model); ok && (cfg.Persist || keepNamed && !slices.Equal(f.named, saved.Named)) {
+		s := f.state()
+		if !cfg.Persist {
+			s = saved.withNamed(f.named)
+		}
+		if err := saveState(s); err != nil {
+			fmt.Printf("Error saving state: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if runErr != nil {
+		fmt.Printf("Error occured: %v", runErr)
 		os.Exit(1)
 	}
+
+	if f, ok := final.(model); ok && len(cfg.Send) > 0 {
+		if err := sendValue(cfg.Send, f.value()); err != nil {
+			fmt.Printf("Error sending value: %v\n", err)
+			os.Exit(1)
+		}
+	}
 }
@@ -0,0 +1,34 @@
+package main
+
+import "github.com/charmbracelet/lipgloss"
+
+var maskStyle = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color("3"))
+
+// setMask highlights the bits of the mask written in input. An empty input
+// clears the mask.
+func (m *model) setMask(input string) error {
+	if len(input) == 0 {
+		m.mask = 0
+		return nil
+	}
+
+	v, _, err := parseLiteral(input, Hexadecimal)
+	if err != nil {
+		return err
+	}
+	m.mask = v
+	return nil
+}
+
+// masked reports whether the digit at position i of the n-digit row of
+// radix r covers any bit of the mask.
+func (m model) masked(r radix, i, n int) bool {
+	pos := uint(n - 1 - i)
+	switch r {
+	case Binary:
+		return m.mask>>pos&1 != 0
+	case Hexadecimal:
+		return pos < 16 && m.mask>>(4*pos)&0xF != 0
+	}
+	return false
+}
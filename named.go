@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// namedSlot is a value stored under a name, shown in the sidebar and kept
+// across sessions.
+type namedSlot struct {
+	Name  string `json:"name"`
+	Value uint64 `json:"value"`
+}
+
+// maxNamed is how many named slots can be recalled with alt+1 to alt+9.
+const maxNamed = 9
+
+var sidebarStyle = lipgloss.NewStyle().PaddingLeft(4)
+
+// storeNamed stores the current value under name, replacing the slot of
+// that name if there is one.
+func (m *model) storeNamed(name string) error {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return errors.New("a named slot needs a name")
+	}
+
+	for i, s := range m.named {
+		if s.Name == name {
+			m.named[i].Value = m.value()
+			return nil
+		}
+	}
+	if len(m.named) == maxNamed {
+		return fmt.Errorf("there are already %d named slots", maxNamed)
+	}
+	m.named = append(m.named, namedSlot{Name: name, Value: m.value()})
+	return nil
+}
+
+// recallNamed sets the value to that of the named slot at index i.
+func (m *model) recallNamed(i int) {
+	if i < len(m.named) {
+		m.setValue(m.named[i].Value)
+	}
+}
+
+// withSidebar shows the named slots to the right of view, if there are any.
+func (m model) withSidebar(view string) string {
+	if len(m.named) == 0 {
+		return view
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, view, m.sidebarView())
+}
+
+// sidebarView lists the named slots with the key recalling each.
+func (m model) sidebarView() string {
+	b := strings.Builder{}
+	b.WriteString("named\n")
+	for i, s := range m.named {
+		b.WriteString(fmt.Sprintf("%d %s: %s\n", i+1, s.Name, literal(s.Value, m.mode)))
+	}
+	return sidebarStyle.Render(b.String())
+}
@@ -0,0 +1,35 @@
+package main
+
+import "fmt"
+
+// updateNavigate handles a key press in navigate mode, where digits build
+// a count for the next motion instead of being inserted. It reports whether
+// the key was handled; other keys fall through to their usual action.
+func (m *model) updateNavigate(key string) bool {
+	if len(key) == 1 && '0' <= key[0] && key[0] <= '9' && (key[0] != '0' || m.count > 0) {
+		m.count = m.count*10 + int(key[0]-'0')
+		return true
+	}
+
+	n := max(m.count, 1)
+	m.count = 0
+
+	action, ok := navigateActions[key]
+	if !ok {
+		return false
+	}
+	action(m, n)
+	return true
+}
+
+// modeLine describes the navigate mode and any pending count, or is empty
+// when editing.
+func (m model) modeLine() string {
+	if !m.navigate {
+		return ""
+	}
+	if m.count > 0 {
+		return fmt.Sprintf("NAVIGATE %d", m.count)
+	}
+	return "NAVIGATE"
+}
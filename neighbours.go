@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// neighboursView shows the conversions of the value and the values either
+// side of it, one per line. Neighbours beyond 0 or the 64-bit maximum are
+// left out.
+func (m model) neighboursView() string {
+	v := m.value()
+	type neighbour struct {
+		label  string
+		digits [4]string
+	}
+
+	var rows []neighbour
+	if v > 0 {
+		rows = append(rows, neighbour{"-1", formatAllValues(v - 1)})
+	}
+	rows = append(rows, neighbour{" 0", formatAllValues(v)})
+	if v < math.MaxUint64 {
+		rows = append(rows, neighbour{"+1", formatAllValues(v + 1)})
+	}
+
+	var widths [4]int
+	for r := Binary; r <= Hexadecimal; r++ {
+		widths[r] = len(formatMode(r))
+		for _, n := range rows {
+			widths[r] = max(widths[r], len(n.digits[r]))
+		}
+	}
+
+	b := strings.Builder{}
+	b.WriteString("\n   ")
+	for r := Binary; r <= Hexadecimal; r++ {
+		b.WriteString(fmt.Sprintf("  %*s", widths[r], formatMode(r)))
+	}
+	b.WriteString("\n")
+	for _, n := range rows {
+		b.WriteString(n.label + ":")
+		for r := Binary; r <= Hexadecimal; r++ {
+			b.WriteString(fmt.Sprintf("  %*s", widths[r], n.digits[r]))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
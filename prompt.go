@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prompt is a one-line text input shown below the conversions. On enter,
+// accept is called with the entered text.
+type prompt struct {
+	label  string
+	input  string
+	accept func(m *model, input string) error
+}
+
+func (m *model) openPrompt(label string, accept func(*model, string) error) {
+	m.prompt = &prompt{label: label, accept: accept}
+}
+
+// updatePrompt handles a key press while a prompt is open.
+func (m *model) updatePrompt(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		p := m.prompt
+		m.prompt = nil
+		if err := p.accept(m, p.input); err != nil {
+			m.err = err
+		}
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.prompt = nil
+	case tea.KeyBackspace:
+		if r := []rune(m.prompt.input); len(r) > 0 {
+			m.prompt.input = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.prompt.input += string(msg.Runes)
+	}
+}
+
+func (p prompt) View() string {
+	return fmt.Sprintf("%s: %s█", p.label, p.input)
+}
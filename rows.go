@@ -0,0 +1,483 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Row is an extra read-only row derived from the current value, shown
+// below the conversions.
+type Row interface {
+	Label() string
+	Render(value uint64, width int) string
+}
+
+// patternRow is a Row that can also read the value as the raw bit pattern
+// of the current width, rather than as a number.
+type patternRow interface {
+	Row
+	RenderPattern(bits uint64, width int) string
+}
+
+// swatchRow is a Row of a color, shown with a swatch of it before the text.
+type swatchRow interface {
+	Row
+	Swatch(value uint64) (hex string, ok bool)
+}
+
+type funcRow struct {
+	label  string
+	render func(v uint64) string
+}
+
+func (r funcRow) Label() string {
+	return r.label
+}
+
+func (r funcRow) Render(value uint64, width int) string {
+	return r.render(value)
+}
+
+type extraRow struct {
+	key string
+	row Row
+}
+
+var extraRows []extraRow
+
+// RegisterRow adds row to the extra rows. A row with a key starts hidden
+// and is toggled by pressing the key; a row without one is always shown.
+// It panics if the key is a digit, is bound to an action in either mode or
+// toggles another row, since the row could then never be toggled or would
+// take the key over.
+func RegisterRow(key string, row Row) {
+	if len(key) > 0 {
+		if _, err := parse(key, Hexadecimal); err == nil || boundKey(key) || extraRowIndex(key) >= 0 {
+			panic(fmt.Sprintf("RegisterRow: key %q of row %q is already in use", key, row.Label()))
+		}
+	}
+	extraRows = append(extraRows, extraRow{key: key, row: row})
+}
+
+// rowShown reports whether the extra row at index i is shown.
+func (m model) rowShown(i int) bool {
+	return len(extraRows[i].key) == 0 || i < len(m.shownRows) && m.shownRows[i]
+}
+
+// showRow shows or hides the extra row at index i, growing shownRows for
+// rows registered after the model was made.
+func (m *model) showRow(i int, show bool) {
+	for len(m.shownRows) <= i {
+		m.shownRows = append(m.shownRows, false)
+	}
+	m.shownRows[i] = show
+}
+
+func init() {
+	RegisterRow("r", funcRow{"rune", runeLiteral})
+	RegisterRow("V", funcRow{"valid rune", validRune})
+	RegisterRow("!", funcRow{"factoradic", factoradic})
+	RegisterRow("%", funcRow{"digits", digitHistogram})
+	RegisterRow("y", funcRow{"lengths", digitCounts})
+	RegisterRow("=", funcRow{"weights", bitWeights})
+	RegisterRow("m", signMagnitudeRow{})
+	RegisterRow(".", funcRow{"ipv4", ipv4})
+	RegisterRow(":", funcRow{"mac", mac})
+	RegisterRow("N", funcRow{"negadecimal", negadecimal})
+	RegisterRow("T", funcRow{"unix time", unixTime})
+	RegisterRow("K", funcRow{"filetime", filetime})
+	RegisterRow("H", funcRow{"duration", duration})
+	RegisterRow("X", hexdumpRow{})
+	RegisterRow("L", funcRow{"luhn", withLuhn})
+	RegisterRow("'", funcRow{"superscript", superscript})
+	RegisterRow("?", funcRow{"nato", nato})
+	RegisterRow("&", funcRow{"varint", varint})
+	RegisterRow(";", leb128Row{})
+	RegisterRow("Z", funcRow{"collatz", collatz})
+}
+
+// signMagnitudeRow reads the value as a sign bit followed by the magnitude
+// at the current width.
+type signMagnitudeRow struct{}
+
+func (signMagnitudeRow) Label() string {
+	return "sign-magnitude"
+}
+
+func (signMagnitudeRow) Render(value uint64, width int) string {
+	value &= mask(width)
+	if !signBit(value, width) {
+		return strconv.FormatUint(value, 10)
+	}
+	return "-" + strconv.FormatUint(value&mask(width-1), 10)
+}
+
+// hexdumpRow shows the bytes of the current width, most significant first,
+// laid out like a line of xxd: offset, hex pairs and an ASCII gutter.
+type hexdumpRow struct{}
+
+func (hexdumpRow) Label() string {
+	return "hexdump"
+}
+
+func (hexdumpRow) Render(value uint64, width int) string {
+	n := max(1, (width+7)/8)
+	hex := strings.Builder{}
+	ascii := strings.Builder{}
+	for i := n - 1; i >= 0; i-- {
+		c := byte(value >> (8 * i))
+		hex.WriteString(fmt.Sprintf("%02x", c))
+		if i%2 == 0 && i > 0 {
+			hex.WriteByte(' ')
+		}
+		if c < ' ' || c > '~' {
+			c = '.'
+		}
+		ascii.WriteByte(c)
+	}
+	return fmt.Sprintf("00000000: %s  %s", hex.String(), ascii.String())
+}
+
+// extraRowIndex returns the index of the extra row toggled by key, or -1.
+func extraRowIndex(key string) int {
+	for i, row := range extraRows {
+		if len(row.key) > 0 && row.key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// extraRowByLabel returns the index of the extra row labelled label, or -1.
+func extraRowByLabel(label string) int {
+	for i, row := range extraRows {
+		if row.row.Label() == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// ipv4 returns v as a dotted IPv4 address.
+func ipv4(v uint64) string {
+	if v > math.MaxUint32 {
+		return "does not fit in 32 bits"
+	}
+	return fmt.Sprintf("%d.%d.%d.%d", v>>24, v>>16&0xFF, v>>8&0xFF, v&0xFF)
+}
+
+// mac returns v as a colon-separated MAC address.
+func mac(v uint64) string {
+	if v > 1<<48-1 {
+		return "does not fit in 48 bits"
+	}
+
+	parts := make([]string, 6)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("%02x", byte(v>>(8*(5-i))))
+	}
+	return strings.Join(parts, ":")
+}
+
+// negadecimal returns v in base -10, where the digit at position i has the
+// weight (-10)^i.
+func negadecimal(v uint64) string {
+	digits := []byte{byte('0' + v%10)}
+
+	// v/10 fits in an int64, so the rest is worked out signed.
+	n := -int64(v / 10)
+	for n != 0 {
+		r := n % -10
+		n /= -10
+		if r < 0 {
+			r += 10
+			n++
+		}
+		digits = append(digits, byte('0'+r))
+	}
+
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// unixTime returns v as a Unix timestamp in seconds, in UTC.
+func unixTime(v uint64) string {
+	if v > math.MaxInt64 {
+		return "out of range"
+	}
+	t := time.Unix(int64(v), 0).UTC()
+	if t.Year() > 9999 {
+		return "out of range"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// filetimeEpoch is the Unix time of 1601-01-01, the Windows FILETIME epoch.
+const filetimeEpoch = -11644473600
+
+// filetime returns v as a Windows FILETIME, counting 100-nanosecond
+// intervals since 1601, in UTC.
+func filetime(v uint64) string {
+	t := time.Unix(int64(v/1e7)+filetimeEpoch, int64(v%1e7)*100).UTC()
+	if t.Year() > 9999 {
+		return "out of range"
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// duration returns v as a Go time.Duration in nanoseconds, broken down
+// into days, hours, minutes, seconds and nanoseconds.
+func duration(v uint64) string {
+	if v > math.MaxInt64 {
+		return "out of range"
+	}
+
+	d := time.Duration(v)
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	return fmt.Sprintf("%s = %dd %dh %dm %ds %dns", time.Duration(v), days,
+		d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second, d%time.Second)
+}
+
+// validRune reports whether v is a Unicode code point that may be encoded
+// as UTF-8, which excludes surrogates.
+func validRune(v uint64) string {
+	if v <= utf8.MaxRune && utf8.ValidRune(rune(v)) {
+		return "yes"
+	}
+	return "no"
+}
+
+// withLuhn returns v in decimal with its Luhn check digit appended.
+func withLuhn(v uint64) string {
+	digits := formatValue(v, Decimal)
+
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		// Double every second digit from the right, counting the check
+		// digit about to be appended.
+		if (len(digits)-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return digits + strconv.Itoa((10-sum%10)%10)
+}
+
+var superscriptDigits = []rune("⁰¹²³⁴⁵⁶⁷⁸⁹")
+
+// superscript returns v in decimal with superscript digits, like "¹²³".
+func superscript(v uint64) string {
+	b := strings.Builder{}
+	for _, c := range formatValue(v, Decimal) {
+		b.WriteRune(superscriptDigits[c-'0'])
+	}
+	return b.String()
+}
+
+var natoLetters = map[rune]string{
+	'A': "Alfa", 'B': "Bravo", 'C': "Charlie",
+	'D': "Delta", 'E': "Echo", 'F': "Foxtrot",
+}
+
+// nato spells v in upper-case hex with the NATO phonetic alphabet, for
+// reading it out, like "Foxtrot Alfa" for FA. Digits stay numerals.
+func nato(v uint64) string {
+	var words []string
+	for _, c := range strings.ToUpper(formatValue(v, Hexadecimal)) {
+		if w, ok := natoLetters[c]; ok {
+			words = append(words, w)
+		} else {
+			words = append(words, string(c))
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// valueBytes returns the bytes of v, most significant first, without
+// leading zero bytes.
+func valueBytes(v uint64) []byte {
+	b := []byte{byte(v)}
+	for v >>= 8; v > 0; v >>= 8 {
+		b = append([]byte{byte(v)}, b...)
+	}
+	return b
+}
+
+// varint decodes the bytes of v, most significant first, as a protobuf
+// varint, like AC 02 to 300, noting how many bytes it took.
+func varint(v uint64) string {
+	b := valueBytes(v)
+
+	var x uint64
+	for i, c := range b {
+		x |= uint64(c&0x7F) << (7 * i)
+		if c&0x80 == 0 {
+			n := i + 1
+			unit := "bytes"
+			if n == 1 {
+				unit = "byte"
+			}
+			if n < len(b) {
+				return fmt.Sprintf("%d (%d %s, %d left over)", x, n, unit, len(b)-n)
+			}
+			return fmt.Sprintf("%d (%d %s)", x, n, unit)
+		}
+	}
+	return "invalid, the last byte has the continuation bit set"
+}
+
+// leb128Row shows the LEB128 encodings of the value, unsigned and read as
+// two's complement at the current width, as bytecode operands are written.
+type leb128Row struct{}
+
+func (leb128Row) Label() string {
+	return "leb128"
+}
+
+func (leb128Row) Render(value uint64, width int) string {
+	return fmt.Sprintf("unsigned %s  signed %s",
+		hexBytes(uleb128(value)), hexBytes(sleb128(signedValue(value&mask(width), width))))
+}
+
+// uleb128 returns the unsigned LEB128 encoding of v, like AC 02 for 300.
+func uleb128(v uint64) []byte {
+	var b []byte
+	for {
+		c := byte(v & 0x7F)
+		v >>= 7
+		if v == 0 {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+// sleb128 returns the signed LEB128 encoding of n, like 7F for -1.
+func sleb128(n int64) []byte {
+	var b []byte
+	for {
+		c := byte(n & 0x7F)
+		n >>= 7
+		if (n == 0 && c&0x40 == 0) || (n == -1 && c&0x40 != 0) {
+			return append(b, c)
+		}
+		b = append(b, c|0x80)
+	}
+}
+
+// hexBytes returns b as space-separated hex pairs, like "AC 02".
+func hexBytes(b []byte) string {
+	pairs := make([]string, len(b))
+	for i, c := range b {
+		pairs[i] = fmt.Sprintf("%02X", c)
+	}
+	return strings.Join(pairs, " ")
+}
+
+// maxCollatzSteps caps the Collatz sequences followed for the collatz row.
+const maxCollatzSteps = 10000
+
+// collatz returns the number of steps the Collatz sequence of v takes to
+// reach 1, halving even numbers and taking 3n+1 of odd ones.
+func collatz(v uint64) string {
+	if v == 0 {
+		return "undefined for 0"
+	}
+
+	for steps := 0; steps < maxCollatzSteps; steps++ {
+		switch {
+		case v == 1:
+			return strconv.Itoa(steps)
+		case v%2 == 0:
+			v /= 2
+		case v > (math.MaxUint64-1)/3:
+			return fmt.Sprintf("exceeds 64 bits after %d steps", steps)
+		default:
+			v = 3*v + 1
+		}
+	}
+	return fmt.Sprintf("more than %d steps", maxCollatzSteps)
+}
+
+// runeLiteral returns v as a Go rune literal, escaping non-ASCII and
+// non-printable characters.
+func runeLiteral(v uint64) string {
+	if v > utf8.MaxRune || !utf8.ValidRune(rune(v)) {
+		return "invalid"
+	}
+	return strconv.QuoteRuneToASCII(rune(v))
+}
+
+// factoradic returns v in the factorial number system. Digits above 9 are
+// written as letters, as in strconv.
+func factoradic(v uint64) string {
+	if v == 0 {
+		return "0"
+	}
+
+	var digits []byte
+	for radix := uint64(1); v > 0; radix++ {
+		digits = append(digits, strconv.FormatUint(v%radix, 36)[0])
+		v /= radix
+	}
+
+	b := strings.Builder{}
+	for i := len(digits) - 1; i >= 0; i-- {
+		b.WriteByte(digits[i])
+	}
+	return strings.ToUpper(b.String())
+}
+
+// digitHistogram returns a bar per decimal digit occurring in v, as long as
+// the number of times it occurs.
+func digitHistogram(v uint64) string {
+	var counts [10]int
+	for _, c := range strconv.FormatUint(v, 10) {
+		counts[c-'0']++
+	}
+
+	var bars []string
+	for digit, n := range counts {
+		if n > 0 {
+			bars = append(bars, fmt.Sprintf("%d:%s", digit, strings.Repeat("█", n)))
+		}
+	}
+	return strings.Join(bars, " ")
+}
+
+// digitCounts returns the number of digits v takes in each base, like
+// "bin:8 oct:3 dec:3 hex:2" for 255.
+func digitCounts(v uint64) string {
+	var counts []string
+	for r := Binary; r <= Hexadecimal; r++ {
+		counts = append(counts, fmt.Sprintf("%s:%d", formatMode(r), len(formatValue(v, r))))
+	}
+	return strings.Join(counts, " ")
+}
+
+// bitWeights returns the positional weights of the set bits of v summed to
+// v, like "128 + 64 + 8 = 200".
+func bitWeights(v uint64) string {
+	var weights []string
+	for i := 63; i >= 0; i-- {
+		if v&(1<<i) != 0 {
+			weights = append(weights, strconv.FormatUint(1<<i, 10))
+		}
+	}
+
+	if len(weights) == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%s = %d", strings.Join(weights, " + "), v)
+}
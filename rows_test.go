@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+type parityRow struct{}
+
+func (parityRow) Label() string {
+	return "parity"
+}
+
+func (parityRow) Render(value uint64, width int) string {
+	if value%2 == 0 {
+		return "even"
+	}
+	return "odd"
+}
+
+func TestRegisterRow(t *testing.T) {
+	defer func(rows []extraRow) { extraRows = rows }(extraRows)
+
+	// The model exists before the row is registered, so the model must
+	// not size its shown rows from extraRows up front.
+	m := initialModel(config{})
+	RegisterRow("alt+]", parityRow{})
+
+	view := press(m, "7", "alt+]").View()
+	if !strings.Contains(view, "parity: odd") {
+		t.Errorf("view after toggling a registered row doesn't show it:\n%s", view)
+	}
+	view = press(m, "7").View()
+	if strings.Contains(view, "parity") {
+		t.Errorf("registered row is shown before its key is pressed:\n%s", view)
+	}
+}
+
+func TestRegisterRowRejectsKeysInUse(t *testing.T) {
+	for _, key := range []string{"q", "enter", "a", "7", "r", "i", "$"} {
+		t.Run(key, func(t *testing.T) {
+			defer func(rows []extraRow) { extraRows = rows }(extraRows)
+			defer func() {
+				if recover() == nil {
+					t.Errorf("RegisterRow(%q) didn't panic", key)
+				}
+			}()
+			RegisterRow(key, parityRow{})
+		})
+	}
+}
+
+func TestRowsJSONColorRows(t *testing.T) {
+	m := press(initialModel(config{}), "4", "2", "*", ",").(model)
+	got := m.rowsJSON()
+	if strings.Contains(got, "\x1b") || strings.Contains(got, `\u001b`) {
+		t.Errorf("rowsJSON() = %q, has escape sequences", got)
+	}
+	for _, want := range []string{`"color":"#00002A"`, `"rgba":"R=0 G=0 B=0 A=42 (16% opaque)"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rowsJSON() = %s, missing %s", got, want)
+		}
+	}
+}
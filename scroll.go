@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lines returns the number of lines the unscrolled view takes up.
+func (m model) lines() int {
+	return len(splitLines(m.content()))
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// scrollBy moves the view n lines down, or up if n is negative, keeping as
+// much of it on screen as the terminal height allows.
+func (m *model) scrollBy(n int) {
+	m.scroll = clamp(m.scroll+n, 0, max(0, m.lines()-m.pageHeight()))
+}
+
+// pageHeight is the number of lines of content shown at once, leaving one
+// line for the scroll position and those around the content.
+func (m model) pageHeight() int {
+	return max(1, m.height-1-m.chromeLines())
+}
+
+// page clips s to the terminal height, starting at the scroll offset, and
+// notes which lines are shown. s is returned unchanged if it fits or the
+// height is not known yet.
+func (m model) page(s string) string {
+	lines := splitLines(s)
+	if m.height <= 0 || len(lines) <= m.height-m.chromeLines() {
+		return s
+	}
+
+	n := m.pageHeight()
+	start := clamp(m.scroll, 0, len(lines)-n)
+	end := start + n
+	return strings.Join(lines[start:end], "\n") +
+		fmt.Sprintf("\n-- lines %d-%d of %d --", start+1, end, len(lines))
+}
+
+// digitWindow returns the range of the n digits of a row to show when they
+// don't all fit the terminal width, keeping the cursor position c in view.
+// The digits before the cursor are kept in view, or those after it if
+// anchorAfter is set. size and sep are the digit grouping in use, if any.
+func (m model) digitWindow(n, c, size int, sep string) (int, int) {
+	// Leave room for the label and an ellipsis on either side.
+	cells := m.columns - m.chromeColumns() - len("bin: ") - 2
+	visible := cells
+	if m.grouping && size > 0 {
+		visible = cells * size / (size + len(sep))
+	}
+	if m.columns <= 0 || n < visible {
+		return 0, n
+	}
+	visible = max(visible, 1)
+
+	start := c - visible + 1
+	if m.anchorAfter {
+		start = c
+	}
+	start = clamp(start, 0, n-visible)
+	return start, start + visible
+}
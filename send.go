@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+)
+
+// sendValue writes v in decimal, followed by a newline, to the Unix socket
+// or named pipe at path, for another process to consume. Opening a named
+// pipe waits for a reader.
+func sendValue(path string, v uint64) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	line := fmt.Sprintf("%d\n", v)
+	switch mode := info.Mode(); {
+	case mode&os.ModeSocket != 0:
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return err
+		}
+		if _, err := conn.Write([]byte(line)); err != nil {
+			conn.Close()
+			return err
+		}
+		return conn.Close()
+	case mode&os.ModeNamedPipe != 0:
+		f, err := os.OpenFile(path, os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		if _, err := f.WriteString(line); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+	return fmt.Errorf("%s is not a socket or named pipe", path)
+}
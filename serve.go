@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// serve runs an HTTP server on addr converting values on GET
+// /convert?v=FF&from=hex&to=dec. from is detected from the value's prefix
+// or suffix if unset, and to defaults to decimal. signed=true accepts
+// negative values and prints signed decimals. The result is plain text, or
+// JSON with format=json.
+func serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/convert", handleConvert)
+	return http.ListenAndServe(addr, mux)
+}
+
+func handleConvert(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	q := req.URL.Query()
+	c, err := queryConverter(q.Get("from"), q.Get("to"), q.Get("signed"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	result, err := c.Convert(q.Get("v"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if q.Get("format") == "json" {
+		body := bytes.Buffer{}
+		err := json.NewEncoder(&body).Encode(map[string]string{
+			"value":  q.Get("v"),
+			"to":     formatMode(c.To),
+			"result": result,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body.Bytes())
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, result)
+}
+
+// queryConverter returns a Converter for the from, to and signed query
+// parameters.
+func queryConverter(from, to, signed string) (Converter, error) {
+	c := Converter{From: Decimal, Detect: len(from) == 0, To: Decimal}
+	if len(signed) > 0 {
+		var err error
+		if c.Signed, err = strconv.ParseBool(signed); err != nil {
+			return c, fmt.Errorf("invalid signed %q, expected true or false", signed)
+		}
+	}
+	if !c.Detect {
+		r, err := parseRadix(from)
+		if err != nil {
+			return c, err
+		}
+		c.From = r
+	}
+	if len(to) > 0 {
+		r, err := parseRadix(to)
+		if err != nil {
+			return c, err
+		}
+		c.To = r
+	}
+	return c, nil
+}
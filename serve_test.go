@@ -0,0 +1,39 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandleConvert(t *testing.T) {
+	tests := []struct {
+		query  string
+		status int
+		body   string
+	}{
+		{"v=FF&from=hex&to=dec", http.StatusOK, "255\n"},
+		{"v=0xFF&to=bin", http.StatusOK, "11111111\n"},
+		{"v=0xFF&to=bin&format=json", http.StatusOK, `{"result":"11111111","to":"bin","value":"0xFF"}` + "\n"},
+		{"v=-1&from=dec&to=hex&signed=true", http.StatusOK, "FFFFFFFFFFFFFFFF\n"},
+		{"v=-1&from=dec&to=hex", http.StatusBadRequest, "-1: negative values are only accepted when signed\n"},
+		{"v=1&signed=maybe", http.StatusBadRequest, "invalid signed \"maybe\", expected true or false\n"},
+		{"v=&to=hex", http.StatusBadRequest, "\"\" has no digits\n"},
+		{"v=-&from=dec&signed=true", http.StatusBadRequest, "\"-\" has no digits\n"},
+		{"v=1&to=base7", http.StatusBadRequest, "unknown base \"base7\"\n"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handleConvert(rec, httptest.NewRequest(http.MethodGet, "/convert?"+tt.query, nil))
+		if rec.Code != tt.status || rec.Body.String() != tt.body {
+			t.Errorf("%s: got %d %q, want %d %q", tt.query, rec.Code, rec.Body.String(), tt.status, tt.body)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	handleConvert(rec, httptest.NewRequest(http.MethodPost, "/convert?v=1", strings.NewReader("")))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST: got %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// settings are the display toggles that can be saved as defaults in the
+// config file.
+type settings struct {
+	Width            int      `json:"width,omitempty"`
+	Signed           bool     `json:"signed,omitempty"`
+	Grouping         bool     `json:"grouping,omitempty"`
+	KeepZeros        bool     `json:"keepZeros,omitempty"`
+	ShowValid        bool     `json:"showValid,omitempty"`
+	Grid             bool     `json:"grid,omitempty"`
+	BigDigits        bool     `json:"bigDigits,omitempty"`
+	ShowRaw          bool     `json:"showRaw,omitempty"`
+	BitPattern       bool     `json:"bitPattern,omitempty"`
+	ShowWidths       bool     `json:"showWidths,omitempty"`
+	MSBLabels        bool     `json:"msbLabels,omitempty"`
+	NibbleHex        bool     `json:"nibbleHex,omitempty"`
+	RightAlign       bool     `json:"rightAlign,omitempty"`
+	Neighbours       bool     `json:"neighbours,omitempty"`
+	QuietOverflow    bool     `json:"quietOverflow,omitempty"`
+	Box              bool     `json:"box,omitempty"`
+	ShadeNibbles     bool     `json:"shadeNibbles,omitempty"`
+	ShowLegend       bool     `json:"showLegend,omitempty"`
+	Checksums        bool     `json:"checksums,omitempty"`
+	TypedSign        bool     `json:"typedSign,omitempty"`
+	EmphasizeCompact bool     `json:"emphasizeCompact,omitempty"`
+	RelativeBits     bool     `json:"relativeBits,omitempty"`
+	AnchorAfter      bool     `json:"anchorAfter,omitempty"`
+	ShowTrail        bool     `json:"showTrail,omitempty"`
+	ShowDiff         bool     `json:"showDiff,omitempty"`
+	Rows             []string `json:"rows,omitempty"`
+}
+
+func (m model) settings() settings {
+	s := settings{
+		Width:            m.width,
+		Signed:           m.dualSigned,
+		Grouping:         m.grouping,
+		KeepZeros:        m.keepZeros,
+		ShowValid:        m.showValid,
+		Grid:             m.grid,
+		BigDigits:        m.bigDigits,
+		ShowRaw:          m.showRaw,
+		BitPattern:       m.bitPattern,
+		ShowWidths:       m.showWidths,
+		MSBLabels:        m.msbLabels,
+		NibbleHex:        m.nibbleHex,
+		RightAlign:       m.rightAlign,
+		Neighbours:       m.showNeighbours,
+		QuietOverflow:    m.quietOverflow,
+		Box:              m.box,
+		ShadeNibbles:     m.shadeNibbles,
+		ShowLegend:       m.showLegend,
+		Checksums:        m.checksums,
+		TypedSign:        m.typedSign,
+		EmphasizeCompact: m.emphasizeCompact,
+		RelativeBits:     m.relativeBits,
+		AnchorAfter:      m.anchorAfter,
+		ShowTrail:        m.showTrail,
+		ShowDiff:         m.showDiff,
+	}
+	for i, row := range extraRows {
+		if len(row.key) > 0 && m.rowShown(i) {
+			s.Rows = append(s.Rows, row.key)
+		}
+	}
+	return s
+}
+
+func (m *model) applySettings(s settings) {
+	if s.Width > 0 {
+		m.width = s.Width
+	}
+	m.dualSigned = s.Signed
+	m.grouping = s.Grouping
+	m.keepZeros = s.KeepZeros
+	m.showValid = s.ShowValid
+	m.grid = s.Grid
+	m.bigDigits = s.BigDigits
+	m.showRaw = s.ShowRaw
+	m.bitPattern = s.BitPattern
+	m.showWidths = s.ShowWidths
+	m.msbLabels = s.MSBLabels
+	m.nibbleHex = s.NibbleHex
+	m.rightAlign = s.RightAlign
+	m.showNeighbours = s.Neighbours
+	m.quietOverflow = s.QuietOverflow
+	m.box = s.Box
+	m.shadeNibbles = s.ShadeNibbles
+	m.showLegend = s.ShowLegend
+	m.checksums = s.Checksums
+	m.typedSign = s.TypedSign
+	m.emphasizeCompact = s.EmphasizeCompact
+	m.relativeBits = s.RelativeBits
+	m.anchorAfter = s.AnchorAfter
+	m.showTrail = s.ShowTrail
+	m.showDiff = s.ShowDiff
+	for _, key := range s.Rows {
+		if i := extraRowIndex(key); i >= 0 {
+			m.showRow(i, true)
+		}
+	}
+}
+
+// exportSettings writes the current settings and theme to the config file,
+// keeping the rest of it as is.
+func (m *model) exportSettings() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	doc := map[string]json.RawMessage{}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	} else if err == nil {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+	}
+
+	if doc["settings"], err = json.Marshal(m.settings()); err != nil {
+		return err
+	}
+	if len(m.theme) > 0 {
+		if doc["theme"], err = json.Marshal(m.theme); err != nil {
+			return err
+		}
+	}
+	if data, err = json.MarshalIndent(doc, "", "  "); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	m.status = "exported settings to " + path
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fullSettings returns settings with every field set, so that a field that
+// doesn't survive a round trip shows up as a difference.
+func fullSettings(t *testing.T) settings {
+	var s settings
+	v := reflect.ValueOf(&s).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		switch f := v.Field(i); f.Kind() {
+		case reflect.Bool:
+			f.SetBool(true)
+		case reflect.Int:
+			f.SetInt(16)
+		case reflect.Slice:
+			f.Set(reflect.ValueOf([]string{"r", "="}))
+		default:
+			t.Fatalf("settings.%s has a kind fullSettings doesn't set", v.Type().Field(i).Name)
+		}
+	}
+	return s
+}
+
+func TestSettingsRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	want := fullSettings(t)
+	m := initialModel(config{Separator: defaultSeparator, Theme: defaultTheme, Settings: want})
+	if err := m.exportSettings(); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg.Settings, want) {
+		t.Errorf("exported settings:\ngot  %+v\nwant %+v", cfg.Settings, want)
+	}
+	if cfg.Theme != defaultTheme {
+		t.Errorf("exported theme = %q, want %q", cfg.Theme, defaultTheme)
+	}
+	if got := initialModel(cfg).settings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("settings after loading the export:\ngot  %+v\nwant %+v", got, want)
+	}
+}
+
+// TestSettingsCoverToggles checks that every toggle of the model is either
+// saved with the settings or listed here as state of the session.
+func TestSettingsCoverToggles(t *testing.T) {
+	saved := map[string]bool{"dualSigned": true, "showNeighbours": true}
+	st := reflect.TypeOf(settings{})
+	for i := 0; i < st.NumField(); i++ {
+		name, _, _ := strings.Cut(st.Field(i).Tag.Get("json"), ",")
+		saved[name] = true
+	}
+	session := map[string]bool{
+		"kioskPaused": true, "setTitle": true, "negative": true, "summing": true,
+		"navigate": true, "hasRegister": true, "hasClipValue": true,
+	}
+
+	mt := reflect.TypeOf(model{})
+	for i := 0; i < mt.NumField(); i++ {
+		f := mt.Field(i)
+		if f.Type.Kind() == reflect.Bool && !saved[f.Name] && !session[f.Name] {
+			t.Errorf("model.%s is neither saved with the settings nor listed as session state", f.Name)
+		}
+	}
+}
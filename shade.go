@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// nibbleStyles shade the low and high nibble of each byte of the binary row.
+var nibbleStyles = [2]lipgloss.Style{
+	lipgloss.NewStyle().Foreground(lipgloss.Color("6")),
+	lipgloss.NewStyle().Foreground(lipgloss.Color("5")),
+}
+
+// nibbleStyle returns the style of the binary digit at position i of an
+// n-digit number.
+func nibbleStyle(i, n int) lipgloss.Style {
+	return nibbleStyles[(n-1-i)/4%2]
+}
+
+// nibbleLegend explains the shading of the binary row.
+func nibbleLegend() string {
+	return fmt.Sprintf("\nnibbles: %s low (bits 0-3 of each byte)  %s high (bits 4-7)\n",
+		nibbleStyles[0].Render("■"), nibbleStyles[1].Render("■"))
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// slot is a value kept for comparison with the others. The focused slot's
+// value lives in the model's input while it is edited.
+type slot struct {
+	value   uint64
+	history history
+}
+
+var (
+	slotStyle = lipgloss.NewStyle().PaddingRight(4)
+	diffStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("1"))
+)
+
+// setSlots replaces the slots with values, focusing the first.
+func (m *model) setSlots(values []uint64) {
+	m.slots = make([]slot, len(values))
+	for i, v := range values {
+		m.slots[i] = slot{value: v}
+	}
+	m.slot = 0
+	m.loadSlot()
+}
+
+// loadSlot makes the focused slot's value the one being edited.
+func (m *model) loadSlot() {
+	s := m.slots[m.slot]
+	m.input = formatAll(s.value)
+	m.history = s.history
+	m.raw = ""
+	m.updateCursor(m.cursorPos)
+}
+
+func (m *model) saveSlot() {
+	m.slots[m.slot] = slot{value: m.value(), history: m.history}
+}
+
+// focusSlot focuses slot i, wrapping around at either end.
+func (m *model) focusSlot(i int) {
+	m.saveSlot()
+	m.slot = (i + len(m.slots)) % len(m.slots)
+	m.loadSlot()
+}
+
+// newSlot adds a slot holding a copy of the current value after the
+// focused one and focuses it.
+func (m *model) newSlot() {
+	m.saveSlot()
+	m.slots = append(m.slots[:m.slot+1], append([]slot{{value: m.value()}}, m.slots[m.slot+1:]...)...)
+	m.slot++
+	m.loadSlot()
+}
+
+// splitSlot replaces the focused slot with the high and low halves of its
+// value at the current width, focusing the high half. At an odd width the
+// high half has the extra bit.
+func (m *model) splitSlot() {
+	low := m.width / 2
+	v := m.value() & mask(m.width)
+	m.saveSlot()
+	m.slots[m.slot] = slot{value: v >> low}
+	m.slots = append(m.slots[:m.slot+1], append([]slot{{value: v & mask(low)}}, m.slots[m.slot+1:]...)...)
+	m.loadSlot()
+}
+
+// mergeSlots replaces the focused slot and the one after it with a value
+// made of the first as its high half and the second as its low half at the
+// current width, undoing splitSlot. At an odd width the high half has the
+// extra bit.
+func (m *model) mergeSlots() error {
+	if m.slot+1 >= len(m.slots) {
+		return errMsg{"no slot after the focused one to merge with"}
+	}
+
+	lowBits := m.width / 2
+	highBits := m.width - lowBits
+	m.saveSlot()
+	high, low := m.slots[m.slot].value, m.slots[m.slot+1].value
+	if high > mask(highBits) {
+		return errMsg{fmt.Sprintf("slot %d does not fit in %d bits", m.slot+1, highBits)}
+	}
+	if low > mask(lowBits) {
+		return errMsg{fmt.Sprintf("slot %d does not fit in %d bits", m.slot+2, lowBits)}
+	}
+
+	m.slots[m.slot] = slot{value: high<<lowBits | low}
+	m.slots = append(m.slots[:m.slot+1], m.slots[m.slot+2:]...)
+	m.loadSlot()
+	return nil
+}
+
+// closeSlot removes the focused slot unless it is the last one.
+func (m *model) closeSlot() {
+	if len(m.slots) == 1 {
+		return
+	}
+
+	m.slots = append(m.slots[:m.slot], m.slots[m.slot+1:]...)
+	m.slot = min(m.slot, len(m.slots)-1)
+	m.loadSlot()
+}
+
+// aggregates combine the values of all slots.
+var aggregates = map[string]func(a, b uint64) uint64{
+	"sum":     func(a, b uint64) uint64 { return a + b },
+	"product": func(a, b uint64) uint64 { return a * b },
+	"and":     func(a, b uint64) uint64 { return a & b },
+	"or":      func(a, b uint64) uint64 { return a | b },
+	"xor":     func(a, b uint64) uint64 { return a ^ b },
+	"min":     func(a, b uint64) uint64 { return min(a, b) },
+	"max":     func(a, b uint64) uint64 { return max(a, b) },
+}
+
+// aggregate combines the values of all slots with the operation named op,
+// wrapping around on overflow, and adds a slot holding the result.
+func (m *model) aggregate(op string) error {
+	f, ok := aggregates[strings.ToLower(strings.TrimSpace(op))]
+	if !ok {
+		return errMsg{fmt.Sprintf("unknown operation %q, expected sum, product, and, or, xor, min or max", op)}
+	}
+
+	m.saveSlot()
+	result := m.slots[0].value
+	for _, s := range m.slots[1:] {
+		result = f(result, s.value)
+	}
+
+	m.newSlot()
+	m.setValue(result)
+	return nil
+}
+
+// slotsView renders the slots side by side.
+func (m model) slotsView() string {
+	blocks := make([]string, len(m.slots))
+	for i, s := range m.slots {
+		header := fmt.Sprintf("  %d", i+1)
+		body := ""
+		if i == m.slot {
+			header = fmt.Sprintf("▸ %d", i+1)
+			body = m.rowsView()
+		} else {
+			body = m.plainRowsView(s.value)
+		}
+
+		block := header + "\n" + strings.TrimSuffix(body, "\n")
+		if i < len(m.slots)-1 {
+			block = slotStyle.Render(block)
+		}
+		blocks[i] = block
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, blocks...) + "\n"
+}
+
+// plainRowsView renders the conversions of v without a cursor.
+func (m model) plainRowsView(v uint64) string {
+	b := strings.Builder{}
+	for r := Binary; r <= Hexadecimal; r++ {
+		digits := formatValue(v, r)
+		if m.grouping {
+			digits = group(digits, groupSize(r), m.groupSeparator(r))
+		}
+		b.WriteString(fmt.Sprintf("%s: %s\n", formatMode(r), digits))
+	}
+	return b.String()
+}
+
+// diffView compares the binary of the focused slot with that of the next
+// one, highlighting the bits that differ.
+func (m model) diffView() string {
+	if len(m.slots) < 2 {
+		return "diff: needs a second slot, add one with ctrl+n\n"
+	}
+
+	next := (m.slot + 1) % len(m.slots)
+	return bitDiff(strconv.Itoa(m.slot+1), m.value(), strconv.Itoa(next+1), m.slots[next].value)
+}
+
+// bitDiff shows a and b in binary under their labels, highlighting the bits
+// that differ, and sums up which nibbles they are in.
+func bitDiff(labelA string, a uint64, labelB string, b uint64) string {
+	n := max(len(formatValue(a, Binary)), len(formatValue(b, Binary)))
+	width := max(len(labelA), len(labelB))
+
+	line := func(label string, v uint64) string {
+		digits := fmt.Sprintf("%0*b", n, v)
+		out := strings.Builder{}
+		for i := range digits {
+			if (a^b)>>(n-1-i)&1 != 0 {
+				out.WriteString(diffStyle.Render(digits[i : i+1]))
+			} else {
+				out.WriteByte(digits[i])
+			}
+		}
+		return fmt.Sprintf("%-*s %s\n", width+1, label+":", out.String())
+	}
+
+	count := bits.OnesCount64(a ^ b)
+	summary := fmt.Sprintf("%d bits differ", count)
+	if count == 1 {
+		summary = "1 bit differs"
+	}
+	if nibbles := differingNibbles(a ^ b); len(nibbles) > 0 {
+		summary += ", in " + nibbles
+	}
+
+	return line(labelA, a) + line(labelB, b) + summary + "\n"
+}
+
+// differingNibbles lists the nibbles with set bits in x, counting from the
+// low nibble, like "the low nibble" or "nibbles 0, 2".
+func differingNibbles(x uint64) string {
+	var nibbles []string
+	for i := 0; i < 16; i++ {
+		if x>>(4*i)&0xF != 0 {
+			nibbles = append(nibbles, strconv.Itoa(i))
+		}
+	}
+
+	switch {
+	case len(nibbles) == 0:
+		return ""
+	case x == x&0xF:
+		return "the low nibble"
+	case len(nibbles) == 1:
+		return "nibble " + nibbles[0]
+	}
+	return "nibbles " + strings.Join(nibbles, ", ")
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// stateVersion is the version of the state file's schema. Bump it when
+// changing the schema and migrate older versions in state.migrate.
+const stateVersion = 1
+
+// state is the part of the session restored on the next launch.
+type state struct {
+	Version int    `json:"version"`
+	Value   uint64 `json:"value"`
+	Mode    string `json:"mode"`
+	Cursor  int    `json:"cursor"`
+
+	Named []namedSlot `json:"named,omitempty"`
+}
+
+func statePath() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if len(dir) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "conv", "state.json"), nil
+}
+
+func (m model) state() state {
+	return state{Version: stateVersion, Value: m.value(), Mode: formatMode(m.mode), Cursor: m.cursorPos, Named: m.named}
+}
+
+func (m *model) restoreState(s state) {
+	if r, err := parseRadix(s.Mode); err == nil {
+		m.mode = r
+	}
+	m.input = formatAll(s.Value)
+	m.updateCursor(s.Cursor)
+	m.named = s.Named
+}
+
+// withNamed returns s with the named slots replaced by named, for saving
+// named slots without persist saving the rest of the session.
+func (s state) withNamed(named []namedSlot) state {
+	s.Version = stateVersion
+	s.Named = named
+	return s
+}
+
+// loadState reads the state saved by the last session.
+func loadState() (state, error) {
+	var s state
+
+	path, err := statePath()
+	if err != nil {
+		return s, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return s, err
+	}
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := s.migrate(); err != nil {
+		return s, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// migrate upgrades a state read from an older schema version.
+func (s *state) migrate() error {
+	if s.Version > stateVersion {
+		return fmt.Errorf("state version %d is newer than the supported version %d, starting fresh", s.Version, stateVersion)
+	}
+
+	// Unversioned states predate the version field but share the schema.
+	if s.Version == 0 {
+		s.Version = 1
+	}
+	return nil
+}
+
+// saveState writes s to a temporary file, syncs it and renames it over the
+// state file, so an interrupted write or a concurrent session never leaves
+// a partial state behind.
+func saveState(s state) error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "state-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+type autosaveTickMsg time.Time
+
+func autosaveTick(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(t time.Time) tea.Msg {
+		return autosaveTickMsg(t)
+	})
+}
+
+// autosave saves the state and schedules the next save.
+func (m *model) autosave() tea.Cmd {
+	if err := saveState(m.state()); err != nil {
+		m.err = fmt.Errorf("autosave: %w", err)
+	}
+	return autosaveTick(m.autosaveEvery)
+}
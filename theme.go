@@ -0,0 +1,38 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const defaultTheme = "default"
+
+// themes restyle the highlights of the view. The default theme is the one
+// the styles are declared with.
+var themes = map[string]func(){
+	defaultTheme: func() {},
+	"mono":       monoTheme,
+}
+
+// monoTheme replaces colors with text attributes, for monochrome terminals.
+func monoTheme() {
+	maskStyle = lipgloss.NewStyle().Underline(true)
+	diffStyle = lipgloss.NewStyle().Bold(true)
+	trailStyle = lipgloss.NewStyle().Reverse(true)
+	nibbleStyles = [2]lipgloss.Style{lipgloss.NewStyle(), lipgloss.NewStyle().Bold(true)}
+}
+
+// applyTheme applies the theme called name, or the default theme if name is
+// empty. An unknown name leaves the default theme in place.
+func applyTheme(name string) error {
+	if len(name) == 0 {
+		return nil
+	}
+	apply, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown theme %q, using %s", name, defaultTheme)
+	}
+	apply()
+	return nil
+}
@@ -0,0 +1,17 @@
+package main
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// title returns the terminal title showing the value in the focused base.
+func (m model) title() string {
+	return "conv: " + literal(m.value(), m.mode)
+}
+
+// updateTitle returns a command setting the terminal title if it no longer
+// matches old, or nil if it does or titles are disabled.
+func (m model) updateTitle(old string) tea.Cmd {
+	if !m.setTitle || m.title() == old {
+		return nil
+	}
+	return tea.SetWindowTitle(m.title())
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// addToTotal adds the value to the running total and clears the input for
+// the next one.
+func (m *model) addToTotal() error {
+	v := m.value()
+	if m.total > math.MaxUint64-v {
+		return errMsg{"total exceeds 64 bits"}
+	}
+	m.total += v
+	m.summed++
+	m.setValue(0)
+	return nil
+}
+
+// resetTotal clears the running total.
+func (m *model) resetTotal() {
+	m.total = 0
+	m.summed = 0
+}
+
+func (m model) totalView() string {
+	unit := "values"
+	if m.summed == 1 {
+		unit = "value"
+	}
+	return fmt.Sprintf("\ntotal: %d (%d %s, enter adds)\n", m.total, m.summed, unit)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	trailDuration = 600 * time.Millisecond
+	trailInterval = 100 * time.Millisecond
+)
+
+var trailStyle = lipgloss.NewStyle().Background(lipgloss.Color("238"))
+
+// trailPoint is a cursor position left behind in trail mode.
+type trailPoint struct {
+	mode radix
+	pos  int
+	at   time.Time
+}
+
+type trailTickMsg time.Time
+
+func trailTick() tea.Cmd {
+	return tea.Tick(trailInterval, func(t time.Time) tea.Msg {
+		return trailTickMsg(t)
+	})
+}
+
+// leaveTrail marks a position the cursor has just left, starting the decay
+// ticks if the trail was empty.
+func (m *model) leaveTrail(mode radix, pos int) tea.Cmd {
+	start := len(m.trail) == 0
+	m.trail = append(m.trail, trailPoint{mode: mode, pos: pos, at: time.Now()})
+	if start {
+		return trailTick()
+	}
+	return nil
+}
+
+// decayTrail drops the points older than trailDuration, ticking again while
+// any are left.
+func (m *model) decayTrail(now time.Time) tea.Cmd {
+	i := 0
+	for i < len(m.trail) && now.Sub(m.trail[i].at) >= trailDuration {
+		i++
+	}
+	m.trail = m.trail[i:]
+
+	if len(m.trail) > 0 {
+		return trailTick()
+	}
+	return nil
+}
+
+func (m model) inTrail(mode radix, pos int) bool {
+	for _, p := range m.trail {
+		if p.mode == mode && p.pos == pos {
+			return true
+		}
+	}
+	return false
+}
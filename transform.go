@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// reverseNibbles reverses the order of the nibbles in the low width bits of
+// v, which amounts to reversing its bytes and swapping the nibbles of each.
+// Bits above the width are kept. The width must be a multiple of 4.
+func reverseNibbles(v uint64, width int) uint64 {
+	r := bits.ReverseBytes64(v & mask(width))
+	r = (r&0x0F0F0F0F0F0F0F0F)<<4 | (r&0xF0F0F0F0F0F0F0F0)>>4
+	return v&^mask(width) | r>>(64-width)
+}
+
+// mirror reverses the order of the low width bits of v, reflecting them
+// about the width's midpoint. Bits above the width are kept.
+func mirror(v uint64, width int) uint64 {
+	return v&^mask(width) | bits.Reverse64(v&mask(width))>>(64-width)
+}
+
+// reverseDecimal reverses the decimal digits of v, dropping the leading
+// zeros this brings up, like 120 to 21.
+func reverseDecimal(v uint64) (uint64, error) {
+	digits := []byte(formatValue(v, Decimal))
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+
+	r, err := parse(string(digits), Decimal)
+	if err != nil {
+		return v, errMsg{fmt.Sprintf("%s does not fit in 64 bits", digits)}
+	}
+	return r, nil
+}
+
+// reinterpretAsDecimal reads the digits of the focused row as a decimal
+// number and focuses the decimal row.
+func (m *model) reinterpretAsDecimal() error {
+	digits := m.input[m.mode]
+	v, err := parse(digits, Decimal)
+	if err != nil {
+		return errMsg{fmt.Sprintf("%s is not a decimal number", digits)}
+	}
+
+	m.setValue(v)
+	m.mode = Decimal
+	m.updateCursor(m.cursorPos)
+	return nil
+}
+
+// nextPowerOfTwo returns the smallest power of two greater than v, or v if
+// there is none in 64 bits.
+func nextPowerOfTwo(v uint64) uint64 {
+	if v >= 1<<63 {
+		return v
+	}
+	return 1 << bits.Len64(v)
+}
+
+// prevPowerOfTwo returns the greatest power of two less than v, or v if
+// there is none.
+func prevPowerOfTwo(v uint64) uint64 {
+	if v <= 1 {
+		return v
+	}
+	return 1 << (bits.Len64(v-1) - 1)
+}
+
+// fibonacci holds the Fibonacci numbers that fit in 64 bits.
+var fibonacci = func() []uint64 {
+	f := []uint64{0, 1}
+	for {
+		a, b := f[len(f)-2], f[len(f)-1]
+		if a > math.MaxUint64-b {
+			return f
+		}
+		f = append(f, a+b)
+	}
+}()
+
+// nextFibonacci returns the smallest Fibonacci number greater than v, or v
+// if there is none in 64 bits.
+func nextFibonacci(v uint64) uint64 {
+	for _, f := range fibonacci {
+		if f > v {
+			return f
+		}
+	}
+	return v
+}
+
+// prevFibonacci returns the greatest Fibonacci number less than v, or v if
+// there is none.
+func prevFibonacci(v uint64) uint64 {
+	for i := len(fibonacci) - 1; i >= 0; i-- {
+		if fibonacci[i] < v {
+			return fibonacci[i]
+		}
+	}
+	return v
+}
+
+// nextMultiple returns the smallest multiple of step greater than v, or v
+// if there is none in 64 bits.
+func nextMultiple(v, step uint64) uint64 {
+	next := v - v%step
+	if next > math.MaxUint64-step {
+		return v
+	}
+	return next + step
+}
+
+// prevMultiple returns the greatest multiple of step less than v, or v if
+// there is none.
+func prevMultiple(v, step uint64) uint64 {
+	if v%step != 0 {
+		return v - v%step
+	}
+	if v < step {
+		return v
+	}
+	return v - step
+}
+
+// setStep sets the step of the value written in input for stepping between
+// multiples.
+func (m *model) setStep(input string) error {
+	v, _, err := parseLiteral(m.ungroup(input), Decimal)
+	if err != nil {
+		return err
+	}
+	if v == 0 {
+		return errMsg{"step must not be zero"}
+	}
+	m.step = v
+	return nil
+}
+
+// stepMultiple moves the value to the next multiple of the step, or the
+// previous one if prev is set.
+func (m *model) stepMultiple(prev bool) {
+	if m.step == 0 {
+		m.err = errMsg{"no step set, press alt+s to set one"}
+		return
+	}
+	if prev {
+		m.setValue(prevMultiple(m.value(), m.step))
+	} else {
+		m.setValue(nextMultiple(m.value(), m.step))
+	}
+}
+
+// oneHot returns the value with only bit index set, or, if cold is set,
+// with only that bit clear at the given width.
+func oneHot(index uint64, width int, cold bool) (uint64, error) {
+	if index >= uint64(width) {
+		return 0, errMsg{fmt.Sprintf("bit %d is outside the %d-bit width", index, width)}
+	}
+
+	v := uint64(1) << index
+	if cold {
+		v = ^v & mask(width)
+	}
+	return v, nil
+}
+
+const digitSet = "0123456789ABCDEF"
+
+// rotateDigits rotates each digit of s, written in radix r, by n within the
+// base's digits, wrapping around like a Caesar cipher, so that in hex F
+// rotated by 1 is 0. Empty input is a zero, so it rotates too.
+func rotateDigits(s string, r radix, n int) (uint64, error) {
+	base := r.base()
+	n = (n%base + base) % base
+
+	if len(s) == 0 {
+		s = "0"
+	}
+	rotated := []byte(strings.ToUpper(s))
+	for i, c := range rotated {
+		d := strings.IndexByte(digitSet, c)
+		if d < 0 || d >= base {
+			return 0, errMsg{fmt.Sprintf("%q is not a %s digit", c, formatMode(r))}
+		}
+		rotated[i] = digitSet[(d+n)%base]
+	}
+
+	v, err := parse(string(rotated), r)
+	if err != nil {
+		return 0, errMsg{fmt.Sprintf("%s does not fit in 64 bits", rotated)}
+	}
+	return v, nil
+}
+
+// rotateFocused rotates the digits of the focused row by the amount written
+// in input.
+func (m *model) rotateFocused(input string) error {
+	n, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil {
+		return errMsg{fmt.Sprintf("invalid rotation %q", input)}
+	}
+
+	v, err := rotateDigits(m.input[m.mode], m.mode, n)
+	if err != nil {
+		return err
+	}
+	m.setValue(v)
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import "testing"
+
+func TestRotateDigits(t *testing.T) {
+	tests := []struct {
+		in   string
+		r    radix
+		n    int
+		want uint64
+	}{
+		{"", Decimal, 1, 1},
+		{"", Hexadecimal, 0, 0},
+		{"F", Hexadecimal, 1, 0},
+		{"ab", Hexadecimal, 0, 0xAB},
+		{"ab", Hexadecimal, 16, 0xAB},
+		{"ab", Hexadecimal, 1, 0xBC},
+		{"ab", Hexadecimal, -1, 0x9A},
+		{"0f", Hexadecimal, 1, 0x10},
+		{"19", Decimal, 1, 20},
+	}
+	for _, tt := range tests {
+		got, err := rotateDigits(tt.in, tt.r, tt.n)
+		if err != nil || got != tt.want {
+			t.Errorf("rotateDigits(%q, %s, %d) = %#x, %v, want %#x",
+				tt.in, formatMode(tt.r), tt.n, got, err, tt.want)
+		}
+	}
+}
+
+func TestRotateLowerCaseKeepZeros(t *testing.T) {
+	cfg := config{}
+	cfg.Settings = settings{KeepZeros: true}
+	for n, want := range map[string]uint64{"0": 0xAB, "16": 0xAB, "1": 0x1BC} {
+		m := press(initialModel(cfg), "down", "0", "a", "b").(model)
+		if err := m.rotateFocused(n); err != nil || m.value() != want {
+			t.Errorf("rotating 0ab by %s = %#x, %v, want %#x", n, m.value(), err, want)
+		}
+	}
+}
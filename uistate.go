@@ -0,0 +1,104 @@
+package main
+
+// uiState is the observable state of the model, for tests that drive
+// Update with key messages and assert the result without rendering.
+type uiState struct {
+	Input    [4]string
+	Value    uint64
+	Mode     string
+	Cursor   int
+	Width    int
+	Slot     int
+	Slots    int
+	Navigate bool
+	Prompt   string
+
+	// Toggles are the names of the display toggles that are on, and Rows
+	// the labels of the extra rows shown.
+	Toggles []string
+	Rows    []string
+
+	// AngleUnit and FloatOrder are the angle unit and float byte order
+	// cycled to, and Base the extra base shown, empty or 0 when off.
+	AngleUnit  string
+	FloatOrder string
+	Base       int
+
+	Status string
+	Err    string
+}
+
+func (m model) uiState() uiState {
+	s := uiState{
+		Input:    m.input,
+		Value:    m.value(),
+		Mode:     formatMode(m.mode),
+		Cursor:   m.cursorPos,
+		Width:    m.width,
+		Slot:     m.slot,
+		Slots:    len(m.slots),
+		Navigate: m.navigate,
+		Base:     m.base,
+		Status:   m.status,
+	}
+	if m.angleUnit > 0 {
+		s.AngleUnit = angleUnits[m.angleUnit-1].name
+	}
+	if m.floatOrder > 0 {
+		s.FloatOrder = floatOrders[m.floatOrder-1]
+	}
+	if m.prompt != nil {
+		s.Prompt = m.prompt.label
+	}
+	if m.err != nil {
+		s.Err = m.err.Error()
+	}
+
+	toggles := []struct {
+		name string
+		on   bool
+	}{
+		{"grouping", m.grouping},
+		{"keepZeros", m.keepZeros},
+		{"quietOverflow", m.quietOverflow},
+		{"showValid", m.showValid},
+		{"grid", m.grid},
+		{"showTrail", m.showTrail},
+		{"bigDigits", m.bigDigits},
+		{"msbLabels", m.msbLabels},
+		{"nibbleHex", m.nibbleHex},
+		{"showDiff", m.showDiff},
+		{"shadeNibbles", m.shadeNibbles},
+		{"showLegend", m.showLegend},
+		{"anchorAfter", m.anchorAfter},
+		{"dualSigned", m.dualSigned},
+		{"negative", m.negative},
+		{"typedSign", m.typedSign},
+		{"showWidths", m.showWidths},
+		{"checksums", m.checksums},
+		{"neighbours", m.showNeighbours},
+		{"rightAlign", m.rightAlign},
+		{"emphasizeCompact", m.emphasizeCompact},
+		{"summing", m.summing},
+		{"bitPattern", m.bitPattern},
+		{"showRaw", m.showRaw},
+		{"relativeBits", m.relativeBits},
+		{"box", m.box},
+		{"alphabet", m.alphabet != nil},
+		{"clipboardDiff", m.hasClipValue},
+		{"register", m.hasRegister},
+		{"kioskPaused", m.kioskPaused},
+	}
+	for _, t := range toggles {
+		if t.on {
+			s.Toggles = append(s.Toggles, t.name)
+		}
+	}
+
+	for i, row := range extraRows {
+		if len(row.key) > 0 && m.rowShown(i) {
+			s.Rows = append(s.Rows, row.row.Label())
+		}
+	}
+	return s
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// press sends each key to m as a key message, like a user typing them.
+func press(m tea.Model, keys ...string) tea.Model {
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		case "backspace":
+			msg = tea.KeyMsg{Type: tea.KeyBackspace}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		m, _ = m.Update(msg)
+	}
+	return m
+}
+
+func TestUIState(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want uiState
+	}{
+		{
+			name: "typing",
+			keys: []string{"2", "5", "5"},
+			want: uiState{
+				Input:  [4]string{"11111111", "377", "255", "FF"},
+				Value:  255,
+				Mode:   "dec",
+				Cursor: 3,
+				Width:  64,
+				Slots:  1,
+			},
+		},
+		{
+			name: "editing another base",
+			keys: []string{"1", "0", "up", "left", "backspace", "7"},
+			want: uiState{
+				Input:  [4]string{"111010", "72", "58", "3A"},
+				Value:  58,
+				Mode:   "oct",
+				Cursor: 1,
+				Width:  64,
+				Slots:  1,
+			},
+		},
+		{
+			name: "toggles and rows",
+			keys: []string{"6", "5", "g", "w", "S", "r"},
+			want: uiState{
+				Input:   [4]string{"1000001", "101", "65", "41"},
+				Value:   65,
+				Mode:    "dec",
+				Cursor:  2,
+				Width:   8,
+				Slots:   1,
+				Toggles: []string{"grouping", "dualSigned"},
+				Rows:    []string{"rune"},
+			},
+		},
+		{
+			name: "modes",
+			keys: []string{"alt+,", "alt+;", "alt+=", "alt+=", "alt+g", "_", "1", "2", "enter"},
+			want: uiState{
+				Input:      [4]string{"", "", "", ""},
+				Mode:       "dec",
+				Width:      64,
+				Slots:      1,
+				Toggles:    []string{"relativeBits", "box"},
+				AngleUnit:  "deg",
+				FloatOrder: "little-endian",
+				Base:       12,
+			},
+		},
+		{
+			name: "prompt",
+			keys: []string{"M"},
+			want: uiState{
+				Input:  [4]string{"", "", "", ""},
+				Mode:   "dec",
+				Width:  64,
+				Slots:  1,
+				Prompt: "mask",
+			},
+		},
+		{
+			name: "invalid digit",
+			keys: []string{"up", "up", "9"},
+			want: uiState{
+				Input: [4]string{"", "", "", ""},
+				Mode:  "bin",
+				Width: 64,
+				Slots: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := press(initialModel(config{Separator: defaultSeparator}), tt.keys...)
+			if got := m.(model).uiState(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("after %q:\ngot  %+v\nwant %+v", tt.keys, got, tt.want)
+			}
+		})
+	}
+}
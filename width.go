@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var widths = []int{8, 16, 32, 64}
+
+// nextWidth returns the first of widths wider than w, wrapping around to
+// the narrowest, so an odd width like 12 steps up to 16.
+func nextWidth(w int) int {
+	for _, width := range widths {
+		if width > w {
+			return width
+		}
+	}
+	return widths[0]
+}
+
+// mask returns a mask of the low width bits.
+func mask(width int) uint64 {
+	if width >= 64 {
+		return ^uint64(0)
+	}
+	return 1<<width - 1
+}
+
+// saturate caps v at the largest value of the given width.
+func saturate(v uint64, width int) uint64 {
+	return min(v, mask(width))
+}
+
+// twosComplement returns -v in two's complement at the given width.
+func twosComplement(v uint64, width int) uint64 {
+	return (^v + 1) & mask(width)
+}
+
+// signBit reports whether the sign bit of v at the given width is set.
+func signBit(v uint64, width int) bool {
+	return v&(1<<(width-1)) != 0
+}
+
+// signedValue interprets the low width bits of v as a two's complement
+// number.
+func signedValue(v uint64, width int) int64 {
+	v &= mask(width)
+	if signBit(v, width) {
+		v |= ^mask(width)
+	}
+	return int64(v)
+}
+
+// widthsView shows the value in hex masked to each of the widths, and to
+// the current width if it is not one of them, noting which widths truncate
+// it.
+func (m model) widthsView() string {
+	b := strings.Builder{}
+	v := m.value()
+
+	shown := widths
+	if !slices.Contains(widths, m.width) {
+		shown = append(slices.Clone(widths), m.width)
+		slices.Sort(shown)
+	}
+
+	b.WriteString("\n")
+	for _, w := range shown {
+		b.WriteString(fmt.Sprintf("%2d: %0*X", w, (w+3)/4, v&mask(w)))
+		if v > mask(w) {
+			b.WriteString("  (truncated)")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package main
+
+import "testing"
+
+var oddWidths = []struct {
+	width int
+	value uint64
+	sign  bool
+	want  int64
+}{
+	{5, 0x0F, false, 15},
+	{5, 0x10, true, -16},
+	{5, 0x1F, true, -1},
+	{12, 0x7FF, false, 2047},
+	{12, 0x800, true, -2048},
+	{12, 0xFFF, true, -1},
+	{12, 0x1FFF, true, -1},
+	{24, 0x7FFFFF, false, 8388607},
+	{24, 0x800000, true, -8388608},
+	{24, 0xFFFFFE, true, -2},
+	{63, 1 << 62, true, -1 << 62},
+}
+
+func TestSignedValueOddWidths(t *testing.T) {
+	for _, tt := range oddWidths {
+		if got := signBit(tt.value, tt.width); got != tt.sign {
+			t.Errorf("signBit(%#x, %d) = %t, want %t", tt.value, tt.width, got, tt.sign)
+		}
+		if got := signedValue(tt.value, tt.width); got != tt.want {
+			t.Errorf("signedValue(%#x, %d) = %d, want %d", tt.value, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestTwosComplementOddWidths(t *testing.T) {
+	for _, tt := range oddWidths {
+		neg := twosComplement(tt.value, tt.width)
+		if neg > mask(tt.width) {
+			t.Errorf("twosComplement(%#x, %d) = %#x, wider than %d bits", tt.value, tt.width, neg, tt.width)
+		}
+		if back := twosComplement(neg, tt.width); back != tt.value&mask(tt.width) {
+			t.Errorf("twosComplement twice of %#x at %d bits = %#x", tt.value, tt.width, back)
+		}
+	}
+}
+
+func TestConverterOddWidths(t *testing.T) {
+	tests := []struct {
+		in    string
+		from  radix
+		to    radix
+		width int
+		want  string
+	}{
+		{"FFF", Hexadecimal, Decimal, 12, "-1"},
+		{"800", Hexadecimal, Decimal, 12, "-2048"},
+		{"7FF", Hexadecimal, Decimal, 12, "2047"},
+		{"-1", Decimal, Hexadecimal, 12, "FFF"},
+		{"-2048", Decimal, Hexadecimal, 12, "800"},
+		{"-1", Decimal, Hexadecimal, 24, "FFFFFF"},
+		{"FFFFFE", Hexadecimal, Decimal, 24, "-2"},
+		{"-16", Decimal, Binary, 5, "10000"},
+	}
+	for _, tt := range tests {
+		c := Converter{From: tt.from, To: tt.to, Width: tt.width, Signed: true}
+		if got, err := c.Convert(tt.in); err != nil || got != tt.want {
+			t.Errorf("%d-bit %s to %s of %q = %q, %v, want %q",
+				tt.width, formatMode(tt.from), formatMode(tt.to), tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"1000", "-2049"} {
+		from := Hexadecimal
+		if in[0] == '-' {
+			from = Decimal
+		}
+		c := Converter{From: from, To: Decimal, Width: 12, Signed: true}
+		if got, err := c.Convert(in); err == nil {
+			t.Errorf("12-bit %q = %q, want an error", in, got)
+		}
+	}
+}
+
+func TestSignedNoteOddWidth(t *testing.T) {
+	cfg := config{}
+	cfg.Settings = settings{Width: 12, Signed: true}
+	m := press(initialModel(cfg), "down", "down", "down", "f", "f", "f").(model)
+	if got, want := m.signedNote(Decimal), "  (signed -1)"; got != want {
+		t.Errorf("signed note of FFF at 12 bits = %q, want %q", got, want)
+	}
+}
+
+func TestSplitMergeOddWidth(t *testing.T) {
+	cfg := config{}
+	cfg.Settings = settings{Width: 13}
+	m := press(initialModel(cfg), "8", "1", "9", "1").(model)
+
+	m.splitSlot()
+	if high, low := m.slots[0].value, m.slots[1].value; high != 0x7F || low != 0x3F {
+		t.Errorf("8191 split at 13 bits = %#x, %#x, want 0x7f, 0x3f", high, low)
+	}
+	if err := m.mergeSlots(); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.value(); got != 8191 {
+		t.Errorf("merging the halves at 13 bits = %d, want 8191", got)
+	}
+}
+
+func TestNextWidthOddWidths(t *testing.T) {
+	for w, want := range map[int]int{5: 8, 8: 16, 12: 16, 24: 32, 48: 64, 64: 8} {
+		if got := nextWidth(w); got != want {
+			t.Errorf("nextWidth(%d) = %d, want %d", w, got, want)
+		}
+	}
+}
+
+func TestReverseNibblesOddWidth(t *testing.T) {
+	cfg := config{}
+	cfg.Settings = settings{Width: 5}
+	m := press(initialModel(cfg), "1", "8", "alt+r").(model)
+	if m.err == nil || m.value() != 18 {
+		t.Errorf("reversing nibbles at 5 bits = %d, %v, want 18 and an error", m.value(), m.err)
+	}
+}